@@ -0,0 +1,122 @@
+package chizuql
+
+import (
+	"context"
+	"fmt"
+)
+
+// Batch is a single statement rendered by BuildBatches.
+type Batch struct {
+	SQL  string
+	Args []any
+}
+
+// BuildBatches renders the query as one or more statements, each binding at most maxParams arguments.
+//
+// Multi-row INSERT queries are split between VALUES rows, repeating the remaining clauses (ON CONFLICT, RETURNING,
+// hints...) in every statement; other queries render as a single batch. When maxParams is zero or negative the dialect
+// MaxPlaceholders budget is used, and an unlimited budget keeps every row in one statement. A row, or a statement that
+// cannot be split, that does not fit in the budget fails with ErrPlaceholderLimit.
+//
+// Each batch is rendered with BuildContext, so hooks run once per statement.
+func (q *Query) BuildBatches(ctx context.Context, maxParams int) ([]Batch, error) {
+	dialect := q.dialect
+	if dialect == nil {
+		dialect = DefaultDialect()
+	}
+
+	if maxParams <= 0 {
+		maxParams = dialect.Features().MaxPlaceholders
+	}
+
+	if q.qType != queryTypeInsert || len(q.insertValues) < 2 || maxParams <= 0 {
+		sql, args, err := q.BuildContext(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		if maxParams > 0 && len(args) > maxParams {
+			detail := fmt.Sprintf("statement needs %d placeholders, %d available", len(args), maxParams)
+
+			return nil, q.placeholderLimitError(dialect, "STATEMENT", detail)
+		}
+
+		return []Batch{{SQL: sql, Args: args}}, nil
+	}
+
+	chunks, err := q.insertChunks(dialect, maxParams)
+	if err != nil {
+		return nil, err
+	}
+
+	batches := make([]Batch, 0, len(chunks))
+
+	for _, rows := range chunks {
+		chunk := *q
+		chunk.insertValues = rows
+
+		sql, args, err := chunk.BuildContext(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		batches = append(batches, Batch{SQL: sql, Args: args})
+	}
+
+	return batches, nil
+}
+
+// insertChunks groups the VALUES rows so that each statement, including the arguments bound outside VALUES, stays
+// within maxParams.
+func (q *Query) insertChunks(dialect Dialect, maxParams int) ([][][]Expression, error) {
+	costs := make([]int, 0, len(q.insertValues))
+
+	for _, row := range q.insertValues {
+		rowCtx := q.renderContext(dialect)
+		for _, value := range row {
+			value.build(rowCtx)
+		}
+
+		costs = append(costs, len(rowCtx.args))
+	}
+
+	first := *q
+	first.insertValues = q.insertValues[:1]
+
+	baseCtx := first.renderContext(dialect)
+	first.render(baseCtx)
+
+	if err := baseCtx.err(); err != nil {
+		return nil, err
+	}
+
+	budget := maxParams - (len(baseCtx.args) - costs[0])
+	chunks := make([][][]Expression, 0)
+	start, used := 0, 0
+
+	for i, cost := range costs {
+		if cost > budget {
+			detail := fmt.Sprintf("row %d needs %d placeholders, %d available", i, cost, budget)
+
+			return nil, q.placeholderLimitError(dialect, "VALUES", detail)
+		}
+
+		if used+cost > budget {
+			chunks = append(chunks, q.insertValues[start:i])
+			start, used = i, 0
+		}
+
+		used += cost
+	}
+
+	return append(chunks, q.insertValues[start:]), nil
+}
+
+// placeholderLimitError builds the ErrPlaceholderLimit error reported by BuildBatches.
+func (q *Query) placeholderLimitError(dialect Dialect, clause, detail string) *BuildError {
+	err := newBuildError(clause, ErrPlaceholderLimit, detail)
+	err.QueryType = string(q.qType)
+	err.Dialect = dialect.Kind()
+
+	return err
+}
@@ -0,0 +1,90 @@
+package chizuql
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestBuildBatchesSplitsInsertRows(t *testing.T) {
+	q := New().
+		WithDialect(DialectPostgres).
+		InsertInto("users", "email", "name").
+		Values("a@example.com", "A").
+		Values("b@example.com", "B").
+		Values("c@example.com", "C").
+		OnConflictDoUpdate([]string{"email"}, Set("name", "updated")).
+		Returning("id")
+
+	batches, err := q.BuildBatches(context.Background(), 5)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []Batch{
+		{
+			SQL:  "INSERT INTO users (email, name) VALUES ($1, $2), ($3, $4) ON CONFLICT (email) DO UPDATE SET name = $5 RETURNING id",
+			Args: []any{"a@example.com", "A", "b@example.com", "B", "updated"},
+		},
+		{
+			SQL:  "INSERT INTO users (email, name) VALUES ($1, $2) ON CONFLICT (email) DO UPDATE SET name = $3 RETURNING id",
+			Args: []any{"c@example.com", "C", "updated"},
+		},
+	}
+
+	if !reflect.DeepEqual(batches, want) {
+		t.Fatalf("unexpected batches.\nwant: %#v\n got: %#v", want, batches)
+	}
+
+	if _, err := q.BuildBatches(context.Background(), 2); !errors.Is(err, ErrPlaceholderLimit) {
+		t.Fatalf("expected ErrPlaceholderLimit, got %v", err)
+	}
+}
+
+func TestBuildBatchesUsesDialectBudget(t *testing.T) {
+	q := New().WithDialect(DialectSQLServer).InsertInto("events", "a", "b")
+	for i := range 1500 {
+		q.Values(i, i)
+	}
+
+	batches, err := q.BuildBatches(context.Background(), 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(batches) != 2 || len(batches[0].Args) != 2100 || len(batches[1].Args) != 900 {
+		t.Fatalf("unexpected batch sizes: %d batches", len(batches))
+	}
+
+	unlimited, err := q.WithDialect(DialectClickHouse).BuildBatches(context.Background(), 0)
+	if err != nil || len(unlimited) != 1 || len(unlimited[0].Args) != 3000 {
+		t.Fatalf("expected a single batch without a placeholder limit, got %d (%v)", len(unlimited), err)
+	}
+}
+
+func TestBuildBatchesSingleStatements(t *testing.T) {
+	batches, err := New().Select("id").From("users").Where(Col("id").Eq(1)).BuildBatches(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !reflect.DeepEqual(batches, []Batch{{SQL: "SELECT id FROM users WHERE (id = ?)", Args: []any{1}}}) {
+		t.Fatalf("unexpected batches: %#v", batches)
+	}
+
+	if _, err := New().Update("users").BuildBatches(context.Background(), 10); !errors.Is(err, ErrMissingSetClause) {
+		t.Fatalf("expected build error, got %v", err)
+	}
+
+	oversized := []*Query{
+		New().InsertInto("t", "a", "b", "c").Values(1, 2, 3),
+		New().Update("t").Set(Set("a", 1), Set("b", 2)).Where(Col("id").Eq(3)),
+	}
+
+	for _, q := range oversized {
+		if _, err := q.BuildBatches(context.Background(), 2); !errors.Is(err, ErrPlaceholderLimit) {
+			t.Fatalf("expected placeholder limit error, got %v", err)
+		}
+	}
+}
@@ -2,7 +2,11 @@ package chizuql
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"fmt"
+	"slices"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -15,27 +19,10 @@ const (
 	queryTypeInsert queryType = "INSERT"
 	queryTypeUpdate queryType = "UPDATE"
 	queryTypeDelete queryType = "DELETE"
+	queryTypeMerge  queryType = "MERGE"
 	queryTypeRaw    queryType = "RAW"
 )
 
-// Dialect controls placeholder rendering and certain dialect-specific clauses.
-type Dialect interface {
-	placeholder(int) string
-}
-
-type insertDialect interface {
-	writeInsertKeyword(sql *strings.Builder, insertIgnore bool)
-	insertIgnoreUsesOnConflict(insertIgnore bool) bool
-}
-
-type dialectKind string
-
-const (
-	dialectMySQL    dialectKind = "mysql"
-	dialectPostgres dialectKind = "postgres"
-	dialectSQLite   dialectKind = "sqlite"
-)
-
 // MySQLReturningMode configures how RETURNING behaves for MySQL builds.
 type MySQLReturningMode int
 
@@ -46,6 +33,28 @@ const (
 	MySQLReturningOmit
 )
 
+// MySQLExcludedMode configures how Excluded references the proposed row in MySQL ON DUPLICATE KEY UPDATE clauses.
+type MySQLExcludedMode int
+
+const (
+	// MySQLExcludedValues renders VALUES(column), supported by every MySQL version but deprecated since 8.0.20.
+	MySQLExcludedValues MySQLExcludedMode = iota
+	// MySQLExcludedRowAlias names the VALUES row (VALUES (...) AS excluded) and renders excluded.column, requiring
+	// MySQL 8.0.19+. INSERT ... SELECT keeps using VALUES(column).
+	MySQLExcludedRowAlias
+)
+
+// MySQLSetOperationsMode configures whether MySQL builds may use INTERSECT and EXCEPT.
+type MySQLSetOperationsMode int
+
+const (
+	// MySQLSetOperationsUnion only allows UNION [ALL], failing INTERSECT and EXCEPT with ErrUnsupportedByDialect as
+	// MySQL before 8.0.31 would.
+	MySQLSetOperationsUnion MySQLSetOperationsMode = iota
+	// MySQLSetOperationsAll allows INTERSECT [ALL] and EXCEPT [ALL], requiring MySQL 8.0.31+.
+	MySQLSetOperationsAll
+)
+
 type lockMode int
 
 const (
@@ -54,25 +63,60 @@ const (
 	lockShare
 )
 
+type limitByClause struct {
+	limit int
+	exprs []Expression
+}
+
+type querySetting struct {
+	name  string
+	value any
+}
+
+type lockWait int
+
+const (
+	lockWaitDefault lockWait = iota
+	lockSkipLocked
+	lockNoWait
+)
+
 type lockClause struct {
 	mode lockMode
+	wait lockWait
+}
+
+func (w lockWait) clause() string {
+	if w == lockNoWait {
+		return "NOWAIT"
+	}
+
+	return "SKIP LOCKED"
+}
+
+func (m lockMode) clause() string {
+	if m == lockShare {
+		return "LOCK IN SHARE MODE"
+	}
+
+	return "FOR UPDATE"
 }
 
 // PlannerHint represents an optimizer/planner hint that may be restricted to specific dialects.
 type PlannerHint struct {
 	sql      string
-	dialects map[dialectKind]struct{}
+	dialects map[DialectKind]struct{}
 }
 
 // OptimizerHint creates a new hint, optionally restricted to specific dialects.
 //
 // When no dialects are provided, the hint is emitted for all dialects. Unknown dialects are ignored.
 func OptimizerHint(sql string, dialects ...Dialect) PlannerHint {
-	accepted := make(map[dialectKind]struct{})
+	accepted := make(map[DialectKind]struct{})
 
 	for _, d := range dialects {
-		if kind, ok := dialectKindOf(d); ok {
-			accepted[kind] = struct{}{}
+		if d != nil {
+			accepted[d.Kind()] = struct{}{}
 		}
 	}
 
@@ -90,153 +134,93 @@ func (h PlannerHint) applies(d Dialect) bool {
 		return h.sql != ""
 	}
 
-	kind, ok := dialectKindOf(d)
-	if !ok {
-		return false
-	}
-
-	_, allowed := h.dialects[kind]
+	_, allowed := h.dialects[d.Kind()]
 
 	return allowed && h.sql != ""
 }
 
-type sqlDialect struct {
-	kind dialectKind
-}
-
-func (d sqlDialect) writeInsertKeyword(sql *strings.Builder, insertIgnore bool) {
-	keyword := "INSERT "
-
-	if insertIgnore && d.kind == dialectMySQL {
-		keyword = "INSERT IGNORE "
-	}
-
-	sql.WriteString(keyword)
-}
-
-func (d sqlDialect) insertIgnoreUsesOnConflict(insertIgnore bool) bool {
-	if !insertIgnore {
-		return false
-	}
+var (
+	defaultMySQLReturningMode   = MySQLReturningStrict
+	defaultMySQLReturningModeMu sync.RWMutex
+)
 
-	return d.kind == dialectPostgres || d.kind == dialectSQLite
-}
+// SetDefaultMySQLReturningMode replaces the package-wide default RETURNING strategy for MySQL builds.
+func SetDefaultMySQLReturningMode(mode MySQLReturningMode) {
+	defaultMySQLReturningModeMu.Lock()
+	defer defaultMySQLReturningModeMu.Unlock()
 
-func (d sqlDialect) Kind() dialectKind {
-	return d.kind
+	defaultMySQLReturningMode = mode
 }
 
-func (d sqlDialect) placeholder(i int) string {
-	switch d.kind {
-	case dialectPostgres:
-		return fmt.Sprintf("$%d", i)
-	default:
-		return "?"
-	}
-}
+// DefaultMySQLReturningMode returns the package-wide RETURNING strategy for MySQL builds.
+func DefaultMySQLReturningMode() MySQLReturningMode {
+	defaultMySQLReturningModeMu.RLock()
+	defer defaultMySQLReturningModeMu.RUnlock()
 
-type dialectInspector interface {
-	Kind() dialectKind
+	return defaultMySQLReturningMode
 }
 
-func dialectKindOf(d Dialect) (dialectKind, bool) {
-	if inspected, ok := d.(dialectInspector); ok {
-		return inspected.Kind(), true
-	}
-
-	return "", false
-}
+var (
+	defaultMySQLExcludedMode   = MySQLExcludedValues
+	defaultMySQLExcludedModeMu sync.RWMutex
+)
 
-type dialectCapabilities struct {
-	insert insertDialect
-}
+// SetDefaultMySQLExcludedMode replaces the package-wide strategy used by Excluded on MySQL builds.
+func SetDefaultMySQLExcludedMode(mode MySQLExcludedMode) {
+	defaultMySQLExcludedModeMu.Lock()
+	defer defaultMySQLExcludedModeMu.Unlock()
 
-func newDialectCapabilities(d Dialect) dialectCapabilities {
-	return dialectCapabilities{insert: resolveInsertDialect(d)}
+	defaultMySQLExcludedMode = mode
 }
 
-func resolveInsertDialect(d Dialect) insertDialect {
-	if insert, ok := d.(insertDialect); ok {
-		return insert
-	}
-
-	return defaultInsertDialect{dialect: d}
-}
+// DefaultMySQLExcludedMode returns the package-wide strategy used by Excluded on MySQL builds.
+func DefaultMySQLExcludedMode() MySQLExcludedMode {
+	defaultMySQLExcludedModeMu.RLock()
+	defer defaultMySQLExcludedModeMu.RUnlock()
 
-type defaultInsertDialect struct {
-	dialect Dialect
+	return defaultMySQLExcludedMode
 }
 
-func (d defaultInsertDialect) writeInsertKeyword(sql *strings.Builder, insertIgnore bool) {
-	keyword := "INSERT "
+var (
+	defaultMySQLSetOperationsMode   = MySQLSetOperationsUnion
+	defaultMySQLSetOperationsModeMu sync.RWMutex
+)
 
-	if insertIgnore {
-		if kind, ok := dialectKindOf(d.dialect); ok && kind == dialectMySQL {
-			keyword = "INSERT IGNORE "
-		}
-	}
+// SetDefaultMySQLSetOperationsMode replaces the package-wide INTERSECT/EXCEPT availability for MySQL builds.
+func SetDefaultMySQLSetOperationsMode(mode MySQLSetOperationsMode) {
+	defaultMySQLSetOperationsModeMu.Lock()
+	defer defaultMySQLSetOperationsModeMu.Unlock()
 
-	sql.WriteString(keyword)
+	defaultMySQLSetOperationsMode = mode
 }
 
-func (d defaultInsertDialect) insertIgnoreUsesOnConflict(insertIgnore bool) bool {
-	if !insertIgnore {
-		return false
-	}
-
-	kind, ok := dialectKindOf(d.dialect)
-	if !ok {
-		return false
-	}
+// DefaultMySQLSetOperationsMode returns the package-wide INTERSECT/EXCEPT availability for MySQL builds.
+func DefaultMySQLSetOperationsMode() MySQLSetOperationsMode {
+	defaultMySQLSetOperationsModeMu.RLock()
+	defer defaultMySQLSetOperationsModeMu.RUnlock()
 
-	return kind == dialectPostgres || kind == dialectSQLite
+	return defaultMySQLSetOperationsMode
 }
 
 var (
-	// DialectMySQL renders placeholders as ?
-	DialectMySQL Dialect = sqlDialect{kind: dialectMySQL}
-	// DialectPostgres renders placeholders as $1, $2, ...
-	DialectPostgres Dialect = sqlDialect{kind: dialectPostgres}
-	// DialectSQLite renders placeholders as ? (SQLite-style)
-	DialectSQLite Dialect = sqlDialect{kind: dialectSQLite}
-
-	defaultDialect   Dialect = DialectMySQL
-	defaultDialectMu sync.RWMutex
-
-	defaultMySQLReturningMode   MySQLReturningMode = MySQLReturningStrict
-	defaultMySQLReturningModeMu sync.RWMutex
+	defaultStrictJoins   bool
+	defaultStrictJoinsMu sync.RWMutex
 )
 
-// SetDefaultDialect replaces the package-wide default dialect used by newly created queries.
-func SetDefaultDialect(d Dialect) {
-	defaultDialectMu.Lock()
-	defer defaultDialectMu.Unlock()
-
-	defaultDialect = d
-}
-
-// DefaultDialect returns the package-wide default dialect.
-func DefaultDialect() Dialect {
-	defaultDialectMu.RLock()
-	defer defaultDialectMu.RUnlock()
-
-	return defaultDialect
-}
-
-// SetDefaultMySQLReturningMode replaces the package-wide default RETURNING strategy for MySQL builds.
-func SetDefaultMySQLReturningMode(mode MySQLReturningMode) {
-	defaultMySQLReturningModeMu.Lock()
-	defer defaultMySQLReturningModeMu.Unlock()
+// SetDefaultStrictJoins enables or disables strict joins (see Query.WithStrictJoins) for newly created queries.
+func SetDefaultStrictJoins(enabled bool) {
+	defaultStrictJoinsMu.Lock()
+	defer defaultStrictJoinsMu.Unlock()
 
-	defaultMySQLReturningMode = mode
+	defaultStrictJoins = enabled
 }
 
-// DefaultMySQLReturningMode returns the package-wide RETURNING strategy for MySQL builds.
-func DefaultMySQLReturningMode() MySQLReturningMode {
-	defaultMySQLReturningModeMu.RLock()
-	defer defaultMySQLReturningModeMu.RUnlock()
+// DefaultStrictJoins reports whether newly created queries use strict joins.
+func DefaultStrictJoins() bool {
+	defaultStrictJoinsMu.RLock()
+	defer defaultStrictJoinsMu.RUnlock()
 
-	return defaultMySQLReturningMode
+	return defaultStrictJoins
 }
 
 // Query represents a composable SQL query built using the fluent API.
@@ -246,14 +230,21 @@ type Query struct {
 	dialect Dialect
 
 	mysqlReturningMode MySQLReturningMode
+	mysqlExcludedMode  MySQLExcludedMode
+	mysqlSetOpsMode    MySQLSetOperationsMode
+	identifierQuoting  IdentifierQuoting
+	strictIdentifiers  bool
+	strictJoins        bool
 	insertIgnore       bool
 
-	rawSQL  string
-	rawArgs []any
+	rawSQL       string
+	rawArgs      []any
+	rawNamed     map[string]any
+	rawBindNames bool
 
 	ctes []cte
 
-	unions []unionClause
+	setOps []setOperation
 
 	selectColumns []Expression
 	distinct      bool
@@ -270,25 +261,43 @@ type Query struct {
 	setLimit  *int
 	setOffset *int
 
-	insertTable         TableExpression
-	insertCols          []string
-	insertValues        [][]Expression
-	onConflictTarget    []string
-	onConflictSet       []SetClause
-	onConflictDoNothing bool
+	insertTable           TableExpression
+	insertCols            []string
+	insertValues          [][]Expression
+	insertSelect          *Query
+	insertDefaults        bool
+	onConflictTarget      []string
+	onConflictConstraint  string
+	onConflictTargetWhere Predicate
+	onConflictSet         []SetClause
+	onConflictUpdateWhere Predicate
+	onConflictDoNothing   bool
 
 	updateTable TableExpression
 	setClauses  []SetClause
 
+	allowedColumns map[string]struct{}
+
 	deleteTable TableExpression
 
-	returning []Expression
+	mergeTable  TableExpression
+	mergeSource TableExpression
+	mergeOn     Predicate
+	mergeArms   []mergeArm
+
+	returning     []Expression
+	returningInto []any
 
 	lock lockClause
 
+	limitBy  *limitByClause
+	settings []querySetting
+
 	optimizerHints []PlannerHint
 
 	hooks []BuildHook
+
+	errs []*BuildError
 }
 
 // BuildReport captures metrics from query rendering.
@@ -298,7 +307,7 @@ type BuildReport struct {
 	// ArgsCount is the number of placeholders/arguments emitted.
 	ArgsCount int
 	// DialectKind is the dialect that was used for rendering.
-	DialectKind dialectKind
+	DialectKind DialectKind
 }
 
 // BuildResult is passed to build hooks after SQL generation finishes.
@@ -376,12 +385,45 @@ func SetGlobalBuildHooks(hooks ...BuildHook) {
 
 // New returns a fresh Query instance ready to be composed.
 func New() *Query {
-	return &Query{dialect: DefaultDialect(), mysqlReturningMode: DefaultMySQLReturningMode()}
+	return &Query{
+		dialect:            DefaultDialect(),
+		mysqlReturningMode: DefaultMySQLReturningMode(),
+		mysqlExcludedMode:  DefaultMySQLExcludedMode(),
+		mysqlSetOpsMode:    DefaultMySQLSetOperationsMode(),
+		identifierQuoting:  DefaultIdentifierQuoting(),
+		strictIdentifiers:  DefaultStrictIdentifiers(),
+		strictJoins:        DefaultStrictJoins(),
+	}
 }
 
 // RawQuery builds a query directly from the provided SQL fragment and arguments.
+//
+// Placeholders follow the same rules as Raw: ? markers are rewritten for the dialect, ?? renders a literal ?, and
+// sql.NamedArg arguments bind :name or @name markers.
 func RawQuery(sql string, args ...any) *Query {
-	return &Query{qType: queryTypeRaw, rawSQL: sql, rawArgs: args}
+	q := &Query{qType: queryTypeRaw, rawSQL: sql}
+
+	named, err := namedArgs("RAW", args)
+	if err != nil {
+		q.addError(err)
+
+		return q
+	}
+
+	if named != nil {
+		q.rawNamed = named
+		q.rawBindNames = true
+	} else {
+		q.rawArgs = args
+	}
+
+	return q
+}
+
+// RawQueryNamed builds a query from a SQL fragment whose :name or @name markers are bound from args, following the
+// same rules as RawNamed.
+func RawQueryNamed(sql string, args map[string]any) *Query {
+	return &Query{qType: queryTypeRaw, rawSQL: sql, rawNamed: args, rawBindNames: true}
 }
 
 // WithDialect sets the SQL dialect for placeholder and conflict rendering.
@@ -398,6 +440,48 @@ func (q *Query) WithMySQLReturningMode(mode MySQLReturningMode) *Query {
 	return q
 }
 
+// WithMySQLExcludedMode configures how Excluded is rendered in ON DUPLICATE KEY UPDATE clauses when using the MySQL
+// dialect.
+func (q *Query) WithMySQLExcludedMode(mode MySQLExcludedMode) *Query {
+	q.mysqlExcludedMode = mode
+
+	return q
+}
+
+// WithMySQLSetOperationsMode configures whether Intersect and Except are available when using the MySQL dialect.
+func (q *Query) WithMySQLSetOperationsMode(mode MySQLSetOperationsMode) *Query {
+	q.mysqlSetOpsMode = mode
+
+	return q
+}
+
+// WithIdentifierQuoting configures whether table and column names are quoted with the dialect quote characters.
+func (q *Query) WithIdentifierQuoting(mode IdentifierQuoting) *Query {
+	q.identifierQuoting = mode
+
+	return q
+}
+
+// WithStrictIdentifiers rejects plain strings that are not identifiers (optionally followed by ASC or DESC) wherever
+// they are accepted as SQL fragments, such as Select, OrderBy, GroupBy, Func arguments and Returning. Other fragments
+// must be built explicitly with Raw; violations fail the build with ErrInvalidIdentifier. The setting also covers the
+// subqueries, CTEs and set operation operands of the query; a nested query can only add strictness, never turn it off.
+func (q *Query) WithStrictIdentifiers() *Query {
+	q.strictIdentifiers = true
+
+	return q
+}
+
+// WithStrictJoins rejects joins without an ON or USING condition, failing the build with ErrMissingJoinCondition
+// instead of producing an accidental cartesian product. CrossJoin, NaturalJoin and lateral joins are exempt. The setting
+// also covers the subqueries, CTEs and set operation operands of the query; a nested query can only add strictness,
+// never turn it off.
+func (q *Query) WithStrictJoins() *Query {
+	q.strictJoins = true
+
+	return q
+}
+
 // WithHooks attaches build hooks that will run alongside any global hooks.
 func (q *Query) WithHooks(hooks ...BuildHook) *Query {
 	q.hooks = append(q.hooks, hooks...)
@@ -457,6 +541,37 @@ func (q *Query) Values(values ...any) *Query {
 	return q
 }
 
+// DefaultValues inserts a single row made only of column defaults (INSERT INTO ... DEFAULT VALUES).
+//
+// MySQL renders INSERT INTO ... () VALUES (); dialects without an equivalent (ex: Oracle) fail with
+// ErrUnsupportedByDialect. It cannot be combined with insert columns, Values or FromSelect.
+func (q *Query) DefaultValues() *Query {
+	q.qType = queryTypeInsert
+	q.insertDefaults = true
+
+	return q
+}
+
+// FromSelect makes the INSERT read its rows from a SELECT query (INSERT INTO ... SELECT ...) instead of Values.
+//
+// The source placeholders are numbered in sequence with the rest of the statement, and CTEs declared with With on the
+// outer query are placed where the dialect expects them. Conflict handlers, InsertIgnore and Returning apply as with
+// Values rows; MERGE-based upserts (Oracle) use the source as the USING relation, so its columns must be named after
+// the insert columns.
+func (q *Query) FromSelect(source *Query) *Query {
+	q.qType = queryTypeInsert
+
+	if source == nil {
+		q.addError(newBuildError("INSERT ... SELECT", ErrNilQuery, ""))
+
+		return q
+	}
+
+	q.insertSelect = source
+
+	return q
+}
+
 // OnConflictDoNothing adds a conflict handler that skips inserts when conflicts arise.
 func (q *Query) OnConflictDoNothing(targetColumns ...string) *Query {
 	q.onConflictTarget = targetColumns
@@ -474,6 +589,47 @@ func (q *Query) OnConflictDoUpdate(targetColumns []string, setClauses ...SetClau
 	return q
 }
 
+// OnConflictConstraint targets a named unique constraint instead of columns (ON CONFLICT ON CONSTRAINT name). Pair
+// it with OnConflictDoNothing() or OnConflictDoUpdate(nil, ...).
+//
+// Only PostgreSQL supports constraint targets; MySQL ignores conflict targets altogether.
+func (q *Query) OnConflictConstraint(name string) *Query {
+	q.onConflictConstraint = name
+
+	return q
+}
+
+// OnConflictTargetWhere restricts the conflict target to a partial unique index (ON CONFLICT (column) WHERE ...).
+// Predicates are combined with AND.
+func (q *Query) OnConflictTargetWhere(predicates ...Predicate) *Query {
+	q.onConflictTargetWhere = combinePredicates(q.onConflictTargetWhere, predicates)
+
+	return q
+}
+
+// OnConflictUpdateWhere guards the conflict update so only rows matching the predicates are updated (DO UPDATE SET
+// ... WHERE ...). Predicates are combined with AND; reference the proposed row with Excluded.
+//
+// MERGE-based upserts render the guard on WHEN MATCHED; MySQL cannot express it and fails with ErrUnsupportedByDialect.
+func (q *Query) OnConflictUpdateWhere(predicates ...Predicate) *Query {
+	q.onConflictUpdateWhere = combinePredicates(q.onConflictUpdateWhere, predicates)
+
+	return q
+}
+
+// combinePredicates appends predicates to current with AND.
+func combinePredicates(current Predicate, predicates []Predicate) Predicate {
+	if len(predicates) == 0 {
+		return current
+	}
+
+	if current == nil {
+		return And(predicates...)
+	}
+
+	return And(current, And(predicates...))
+}
+
 // Update starts an UPDATE query.
 func (q *Query) Update(table any) *Query {
 	q.qType = queryTypeUpdate
@@ -524,6 +680,80 @@ func (q *Query) FullJoin(table any, on ...Predicate) *Query {
 	return q.join("FULL JOIN", table, on...)
 }
 
+// JoinUsing adds an INNER JOIN clause matched on columns with the same name in both tables (JOIN ... USING (...)).
+// At least one column is required, otherwise the build fails with ErrInvalidClause. SQL Server rejects USING with
+// ErrUnsupportedByDialect.
+func (q *Query) JoinUsing(table any, columns ...string) *Query {
+	return q.joinUsing("JOIN", table, columns)
+}
+
+// LeftJoinUsing adds a LEFT JOIN ... USING clause. See JoinUsing.
+func (q *Query) LeftJoinUsing(table any, columns ...string) *Query {
+	return q.joinUsing("LEFT JOIN", table, columns)
+}
+
+// RightJoinUsing adds a RIGHT JOIN ... USING clause. See JoinUsing.
+func (q *Query) RightJoinUsing(table any, columns ...string) *Query {
+	return q.joinUsing("RIGHT JOIN", table, columns)
+}
+
+// FullJoinUsing adds a FULL JOIN ... USING clause. See JoinUsing.
+func (q *Query) FullJoinUsing(table any, columns ...string) *Query {
+	return q.joinUsing("FULL JOIN", table, columns)
+}
+
+func (q *Query) joinUsing(kind string, table any, columns []string) *Query {
+	if len(columns) == 0 {
+		q.addError(newBuildError(kind+" ... USING", ErrInvalidClause, "requires at least one column"))
+	}
+
+	q.joins = append(q.joins, joinClause{kind: kind, table: toTableExpression(table), using: columns})
+
+	return q
+}
+
+// CrossJoin adds a CROSS JOIN clause.
+func (q *Query) CrossJoin(table any) *Query {
+	return q.join("CROSS JOIN", table)
+}
+
+// NaturalJoin adds a NATURAL JOIN clause, matching the columns both tables share. SQL Server and ClickHouse reject it
+// with ErrUnsupportedByDialect.
+func (q *Query) NaturalJoin(table any) *Query {
+	return q.join("NATURAL JOIN", table)
+}
+
+// JoinLateral adds a JOIN LATERAL clause, letting a subquery reference the tables joined before it. Without
+// predicates the join renders ON true.
+//
+// Dialects with CROSS APPLY (SQL Server, Oracle) render unconditioned lateral joins as CROSS APPLY and reject
+// predicates, which belong inside the subquery there. Dialects without lateral joins fail with ErrUnsupportedByDialect.
+func (q *Query) JoinLateral(table any, on ...Predicate) *Query {
+	return q.lateralJoin("JOIN", table, on...)
+}
+
+// LeftJoinLateral adds a LEFT JOIN LATERAL clause, rendered as OUTER APPLY where CROSS APPLY is used. See JoinLateral.
+func (q *Query) LeftJoinLateral(table any, on ...Predicate) *Query {
+	return q.lateralJoin("LEFT JOIN", table, on...)
+}
+
+// CrossApply adds a CROSS APPLY clause, rendered as CROSS JOIN LATERAL on dialects with LATERAL.
+func (q *Query) CrossApply(table any) *Query {
+	return q.lateralJoin("CROSS JOIN", table)
+}
+
+// OuterApply adds an OUTER APPLY clause, rendered as LEFT JOIN LATERAL ... ON true on dialects with LATERAL.
+func (q *Query) OuterApply(table any) *Query {
+	return q.lateralJoin("LEFT JOIN", table)
+}
+
+func (q *Query) lateralJoin(kind string, table any, on ...Predicate) *Query {
+	q.join(kind, table, on...)
+	q.joins[len(q.joins)-1].lateral = true
+
+	return q
+}
+
 func (q *Query) join(kind string, table any, on ...Predicate) *Query {
 	clause := joinClause{kind: kind, table: toTableExpression(table)}
 	if len(on) > 0 {
@@ -599,7 +829,9 @@ func (q *Query) OrderBy(expressions ...any) *Query {
 // inverted automatically. Call OrderBy before invoking KeysetAfter.
 func (q *Query) KeysetAfter(cursorValues ...any) *Query {
 	if len(q.orderBy) == 0 {
-		panic("KeysetAfter requer ORDER BY configurado")
+		q.addError(newBuildError("KeysetAfter", ErrInvalidCursor, "ORDER BY must be configured first"))
+
+		return q
 	}
 
 	q.Where(KeysetAfter(q.orderBy, cursorValues...))
@@ -613,7 +845,9 @@ func (q *Query) KeysetAfter(cursorValues ...any) *Query {
 // inverted automatically. Call OrderBy before invoking KeysetBefore.
 func (q *Query) KeysetBefore(cursorValues ...any) *Query {
 	if len(q.orderBy) == 0 {
-		panic("KeysetBefore requer ORDER BY configurado")
+		q.addError(newBuildError("KeysetBefore", ErrInvalidCursor, "ORDER BY must be configured first"))
+
+		return q
 	}
 
 	q.Where(KeysetBefore(q.orderBy, cursorValues...))
@@ -623,25 +857,61 @@ func (q *Query) KeysetBefore(cursorValues ...any) *Query {
 
 // ForUpdate appends a FOR UPDATE lock to the SELECT statement.
 func (q *Query) ForUpdate() *Query {
-	q.ensureLockable()
+	if !q.ensureLockable(lockForUpdate) {
+		return q
+	}
 
-	q.lock = lockClause{mode: lockForUpdate}
+	q.lock.mode = lockForUpdate
 
 	return q
 }
 
 // LockInShareMode appends a shared lock clause (dialect-aware) to the SELECT statement.
 func (q *Query) LockInShareMode() *Query {
-	q.ensureLockable()
+	if !q.ensureLockable(lockShare) {
+		return q
+	}
+
+	q.lock.mode = lockShare
+
+	return q
+}
+
+// SkipLocked makes the row-level lock skip rows locked by other transactions (SKIP LOCKED, READPAST on SQL Server).
+// It must be combined with ForUpdate or LockInShareMode.
+func (q *Query) SkipLocked() *Query {
+	q.lock.wait = lockSkipLocked
 
-	q.lock = lockClause{mode: lockShare}
+	return q
+}
+
+// NoWait makes the row-level lock fail immediately instead of waiting for rows locked by other transactions.
+// It must be combined with ForUpdate or LockInShareMode.
+func (q *Query) NoWait() *Query {
+	q.lock.wait = lockNoWait
+
+	return q
+}
+
+// LimitBy keeps at most limit rows for each distinct combination of expressions (ClickHouse LIMIT n BY ...).
+// It is rendered after ORDER BY and before LIMIT.
+func (q *Query) LimitBy(limit int, expressions ...any) *Query {
+	q.limitBy = &limitByClause{limit: limit, exprs: toSQLExpressions(expressions...)}
+
+	return q
+}
+
+// Setting appends a query-level setting rendered in a trailing SETTINGS clause (ClickHouse). Values are rendered
+// inline and must be strings, booleans or numbers.
+func (q *Query) Setting(name string, value any) *Query {
+	q.settings = append(q.settings, querySetting{name: name, value: value})
 
 	return q
 }
 
 // Limit sets a LIMIT clause.
 func (q *Query) Limit(limit int) *Query {
-	if len(q.unions) > 0 {
+	if len(q.setOps) > 0 {
 		q.setLimit = &limit
 	} else {
 		q.limit = &limit
@@ -652,7 +922,7 @@ func (q *Query) Limit(limit int) *Query {
 
 // Offset sets an OFFSET clause.
 func (q *Query) Offset(offset int) *Query {
-	if len(q.unions) > 0 {
+	if len(q.setOps) > 0 {
 		q.setOffset = &offset
 	} else {
 		q.offset = &offset
@@ -665,10 +935,14 @@ func (q *Query) Offset(offset int) *Query {
 func (q *Query) Returning(expressions ...any) *Query {
 	switch q.qType {
 	case queryTypeSelect:
-		panic("RETURNING is not supported on SELECT queries")
+		q.addError(newBuildError("RETURNING", ErrInvalidClause, "not supported on SELECT queries"))
+
+		return q
 	case queryTypeInsert, queryTypeUpdate, queryTypeDelete:
 	case queryTypeRaw, "":
-		panic("RETURNING requer uma query INSERT, UPDATE ou DELETE")
+		q.addError(newBuildError("RETURNING", ErrInvalidClause, "requires an INSERT, UPDATE or DELETE query"))
+
+		return q
 	}
 
 	q.returning = append(q.returning, toSQLExpressions(expressions...)...)
@@ -676,6 +950,21 @@ func (q *Query) Returning(expressions ...any) *Query {
 	return q
 }
 
+// ReturningInto sets the destinations bound by RETURNING ... INTO on dialects that require them (Oracle), one per
+// Returning expression. Destinations that are not already sql.Out values are wrapped as sql.Out{Dest: dest}.
+// Dialects rendering a plain RETURNING or OUTPUT clause ignore the destinations.
+func (q *Query) ReturningInto(dests ...any) *Query {
+	for _, dest := range dests {
+		if _, ok := dest.(sql.Out); !ok {
+			dest = sql.Out{Dest: dest}
+		}
+
+		q.returningInto = append(q.returningInto, dest)
+	}
+
+	return q
+}
+
 // With adds a common table expression (CTE).
 func (q *Query) With(name string, subquery *Query, columns ...string) *Query {
 	q.ctes = append(q.ctes, cte{name: name, query: subquery, columns: columns})
@@ -691,30 +980,61 @@ func (q *Query) WithRecursive(name string, subquery *Query, columns ...string) *
 }
 
 // Union appends UNION operations with other SELECT queries.
-func (q *Query) Union(queries ...*Query) *Query { return q.union(false, queries...) }
+func (q *Query) Union(queries ...*Query) *Query { return q.setOperation(setUnion, false, queries...) }
 
 // UnionAll appends UNION ALL operations with other SELECT queries.
-func (q *Query) UnionAll(queries ...*Query) *Query { return q.union(true, queries...) }
+func (q *Query) UnionAll(queries ...*Query) *Query { return q.setOperation(setUnion, true, queries...) }
+
+// Intersect appends INTERSECT operations with other SELECT queries.
+//
+// Set operations apply from left to right: when INTERSECT follows a UNION or EXCEPT, the preceding operations are
+// parenthesized so that the tighter INTERSECT precedence of most databases does not regroup them.
+func (q *Query) Intersect(queries ...*Query) *Query {
+	return q.setOperation(setIntersect, false, queries...)
+}
+
+// IntersectAll appends INTERSECT ALL operations with other SELECT queries.
+func (q *Query) IntersectAll(queries ...*Query) *Query {
+	return q.setOperation(setIntersect, true, queries...)
+}
+
+// Except appends EXCEPT operations with other SELECT queries. Oracle renders MINUS.
+func (q *Query) Except(queries ...*Query) *Query { return q.setOperation(setExcept, false, queries...) }
+
+// ExceptAll appends EXCEPT ALL operations with other SELECT queries.
+func (q *Query) ExceptAll(queries ...*Query) *Query {
+	return q.setOperation(setExcept, true, queries...)
+}
+
+func (q *Query) setOperation(op setOperator, all bool, queries ...*Query) *Query {
+	clause := string(op)
 
-func (q *Query) union(all bool, queries ...*Query) *Query {
 	if q.qType != queryTypeSelect {
 		if q.qType == "" {
-			panic("UNION requer uma consulta SELECT inicial")
+			q.addError(newBuildError(clause, ErrInvalidClause, "requires a leading SELECT query"))
+
+			return q
 		}
 
-		panic("UNION pode ser usado apenas em consultas SELECT")
+		q.addError(newBuildError(clause, ErrInvalidClause, "only SELECT queries can be combined"))
+
+		return q
 	}
 
 	for _, other := range queries {
 		if other == nil {
-			panic("UNION requer queries não nulas")
+			q.addError(newBuildError(clause, ErrNilQuery, ""))
+
+			continue
 		}
 
 		if other.qType != queryTypeSelect {
-			panic("UNION aceita apenas queries SELECT como operando")
+			q.addError(newBuildError(clause, ErrInvalidClause, "operands must be SELECT queries"))
+
+			continue
 		}
 
-		q.unions = append(q.unions, unionClause{query: other, all: all})
+		q.setOps = append(q.setOps, setOperation{op: op, query: other, all: all})
 	}
 
 	return q
@@ -763,10 +1083,6 @@ func (q *Query) buildWithContext(ctx context.Context) (BuildResult, error) {
 		return BuildResult{}, err
 	}
 
-	if q.qType == queryTypeInsert && q.insertTable == nil {
-		return BuildResult{}, fmt.Errorf("InsertInto must be called before InsertIgnore/Build for INSERT queries")
-	}
-
 	hooks := q.collectHooks()
 	runBeforeHooks(ctx, hooks, q)
 
@@ -775,17 +1091,18 @@ func (q *Query) buildWithContext(ctx context.Context) (BuildResult, error) {
 		dialect = DefaultDialect()
 	}
 
-	capabilities := newDialectCapabilities(dialect)
-	buildCtx := &buildContext{dialect: dialect, insertDialect: capabilities.insert, mysqlReturning: q.mysqlReturningMode}
+	buildCtx := q.renderContext(dialect)
 	start := time.Now()
 	sql := strings.TrimSpace(q.render(buildCtx))
+
+	if err := buildCtx.err(); err != nil {
+		return BuildResult{}, err
+	}
+
 	report := BuildReport{
 		RenderDuration: time.Since(start),
 		ArgsCount:      len(buildCtx.args),
-	}
-
-	if inspected, ok := dialectKindOf(dialect); ok {
-		report.DialectKind = inspected
+		DialectKind:    dialect.Kind(),
 	}
 
 	result := BuildResult{SQL: sql, Args: buildCtx.args, Report: report}
@@ -799,7 +1116,25 @@ func (q *Query) buildWithContext(ctx context.Context) (BuildResult, error) {
 	return result, nil
 }
 
+// renderContext creates the build context used to render q with the dialect.
+func (q *Query) renderContext(dialect Dialect) *buildContext {
+	ctx := newBuildContext(dialect, q.mysqlReturningMode)
+	ctx.mysqlExcluded = q.mysqlExcludedMode
+	ctx.quoting = q.identifierQuoting
+	ctx.strict = q.strictIdentifiers
+	ctx.strictJoins = q.strictJoins
+
+	if ctx.features.SetOperationsOptIn && q.mysqlSetOpsMode != MySQLSetOperationsAll {
+		ctx.features.SetOperations = SetOperationsUnion
+	}
+
+	return ctx
+}
+
 // Build renders the SQL string and the ordered arguments slice using a background context.
+//
+// Build discards validation errors and returns an empty SQL string when the query is invalid. Use BuildContext to
+// inspect the failure or MustBuild to panic on it.
 func (q *Query) Build() (string, []any) {
 	sql, args, err := q.BuildContext(context.Background())
 	if err != nil {
@@ -809,10 +1144,23 @@ func (q *Query) Build() (string, []any) {
 	return sql, args
 }
 
+// MustBuild renders the SQL string and arguments, panicking with the build error when the query is invalid.
+func (q *Query) MustBuild() (string, []any) {
+	sql, args, err := q.BuildContext(context.Background())
+	if err != nil {
+		panic(err)
+	}
+
+	return sql, args
+}
+
 // BuildContext renders SQL and arguments honoring cancellation and context propagation for telemetry.
 //
 // Hooks receive the provided context, enabling tracing metadata (ex: traceparent) to be propagated without
 // altering the build result. Build metrics remain available to hooks via BuildResult.Report.
+//
+// Every validation failure found while rendering (including those recorded by fluent methods) is returned as an
+// error; when several failures are found they are combined with errors.Join and after hooks are skipped.
 func (q *Query) BuildContext(ctx context.Context) (string, []any, error) {
 	result, err := q.buildWithContext(ctx)
 	if err != nil {
@@ -823,22 +1171,43 @@ func (q *Query) BuildContext(ctx context.Context) (string, []any, error) {
 }
 
 func (q *Query) render(ctx *buildContext) string {
+	parentType, parentStrict := ctx.queryType, ctx.strict
+	ctx.queryType = q.qType
+	ctx.strict = ctx.strict || q.strictIdentifiers
+
+	defer func() { ctx.queryType, ctx.strict = parentType, parentStrict }()
+
+	for _, err := range q.errs {
+		ctx.addError(err)
+	}
+
 	if q.qType == queryTypeRaw {
-		ctx.args = append(ctx.args, q.rawArgs...)
+		if q.rawBindNames {
+			return rewriteNamedPlaceholders(ctx, "RAW", q.rawSQL, q.rawNamed)
+		}
+
+		return rewritePlaceholders(ctx, "RAW", q.rawSQL, q.rawArgs)
+	}
 
-		return q.rawSQL
+	if q.lock.mode == lockNone && q.lock.wait != lockWaitDefault {
+		ctx.addError(newBuildError(q.lock.wait.clause(), ErrInvalidClause, "requires ForUpdate or LockInShareMode"))
 	}
 
-	if q.lock.mode != lockNone && len(q.unions) > 0 {
-		panic("row-level locks não são suportados em consultas UNION/UNION ALL")
+	if q.lock.mode != lockNone && len(q.setOps) > 0 {
+		ctx.addError(newBuildError(q.lock.mode.clause(), ErrInvalidClause, "row-level locks are not supported on set operations"))
 	}
 
+	q.checkAllowedColumns(ctx)
+	q.checkJoinConditions(ctx)
+
 	sql := strings.Builder{}
-	q.writeCTEs(&sql, ctx)
+	if !q.ctesInsideInsertSelect(ctx) {
+		q.writeCTEs(&sql, ctx)
+	}
 
 	switch q.qType {
 	case queryTypeSelect:
-		if len(q.unions) > 0 {
+		if len(q.setOps) > 0 {
 			q.buildSetSelect(&sql, ctx)
 		} else {
 			q.buildSelect(&sql, ctx, true)
@@ -849,10 +1218,14 @@ func (q *Query) render(ctx *buildContext) string {
 		q.buildUpdate(&sql, ctx)
 	case queryTypeDelete:
 		q.buildDelete(&sql, ctx)
+	case queryTypeMerge:
+		q.buildMerge(&sql, ctx)
 	default:
-		panic("query type not set")
+		ctx.addError(newBuildError("", ErrMissingQueryType, ""))
 	}
 
+	q.writeSettings(&sql, ctx)
+
 	return sql.String()
 }
 
@@ -895,6 +1268,13 @@ func (q *Query) buildSelect(sql *strings.Builder, ctx *buildContext, includeOrde
 		sql.WriteString("DISTINCT ")
 	}
 
+	ordered := includeOrdering && len(q.orderBy) > 0
+	top := ctx.features.Limit == LimitOffsetFetch && q.limit != nil && q.offset == nil && !ordered
+
+	if top {
+		fmt.Fprintf(sql, "TOP (%d) ", *q.limit)
+	}
+
 	columns := "*"
 
 	if len(q.selectColumns) > 0 {
@@ -911,53 +1291,181 @@ func (q *Query) buildSelect(sql *strings.Builder, ctx *buildContext, includeOrde
 	if q.from != nil {
 		sql.WriteString(" FROM ")
 		sql.WriteString(q.from.build(ctx))
+		q.writeTableHints(sql, ctx)
 	}
 
-	for _, j := range q.joins {
-		sql.WriteString(" ")
-		sql.WriteString(j.build(ctx))
+	writeJoins(sql, ctx, q.joins)
+	q.buildPredicates(sql, ctx, "WHERE", q.where)
+
+	q.writeGroupBy(sql, ctx)
+	q.buildPredicates(sql, ctx, "HAVING", q.having)
+
+	if includeOrdering {
+		q.appendOrdering(sql, ctx)
 	}
 
-	q.buildPredicates(sql, ctx, "WHERE", q.where)
+	q.writeLimitBy(sql, ctx)
+
+	if !top {
+		q.appendPagination(sql, ctx, q.limit, q.offset, ordered)
+	}
+
+	q.appendLock(sql, ctx)
+}
 
-	if len(q.groupBy) > 0 {
-		parts := make([]string, 0, len(q.groupBy))
-		for _, g := range q.groupBy {
-			parts = append(parts, g.build(ctx))
+func (q *Query) writeGroupBy(sql *strings.Builder, ctx *buildContext) {
+	if len(q.groupBy) == 0 {
+		return
+	}
+
+	if ctx.features.Grouping == GroupingWithModifier {
+		if modifier, elements, ok := groupingModifier(q.groupBy); ok {
+			sql.WriteString(" GROUP BY ")
+			sql.WriteString(buildExpressionList(ctx, elements))
+			sql.WriteString(" WITH ")
+			sql.WriteString(modifier)
+
+			return
 		}
+	}
 
-		sql.WriteString(" GROUP BY ")
-		sql.WriteString(strings.Join(parts, ", "))
+	sql.WriteString(" GROUP BY ")
+	sql.WriteString(buildExpressionList(ctx, q.groupBy))
+}
+
+// groupingModifier reports whether the GROUP BY list is a single ROLLUP or CUBE element, which dialects using
+// GroupingWithModifier render as GROUP BY ... WITH ROLLUP/CUBE.
+func groupingModifier(groupBy []Expression) (string, []Expression, bool) {
+	if len(groupBy) != 1 {
+		return "", nil, false
 	}
 
-	q.buildPredicates(sql, ctx, "HAVING", q.having)
+	switch g := groupBy[0].(type) {
+	case rollupExpr:
+		return "ROLLUP", g.elements, len(g.elements) > 0
+	case cubeExpr:
+		return "CUBE", g.elements, len(g.elements) > 0
+	default:
+		return "", nil, false
+	}
+}
 
-	if includeOrdering {
-		q.appendOrdering(sql, ctx)
+func (q *Query) writeLimitBy(sql *strings.Builder, ctx *buildContext) {
+	if q.limitBy == nil || !requireFeature(ctx, ctx.features.LimitBy, "LIMIT BY") {
+		return
 	}
 
-	q.appendPagination(sql, q.limit, q.offset)
-	q.appendLock(sql, ctx)
+	if len(q.limitBy.exprs) == 0 {
+		ctx.addError(newBuildError("LIMIT BY", ErrInvalidClause, "requires at least one expression"))
+
+		return
+	}
+
+	fmt.Fprintf(sql, " LIMIT %d BY %s", q.limitBy.limit, buildExpressionList(ctx, q.limitBy.exprs))
+}
+
+func (q *Query) writeSettings(sql *strings.Builder, ctx *buildContext) {
+	if len(q.settings) == 0 || !requireFeature(ctx, ctx.features.Settings, "SETTINGS") {
+		return
+	}
+
+	if q.qType != queryTypeSelect {
+		ctx.addError(newBuildError("SETTINGS", ErrInvalidClause, "requires a SELECT query"))
+
+		return
+	}
+
+	parts := make([]string, 0, len(q.settings))
+
+	for _, setting := range q.settings {
+		value, err := settingLiteral(setting.value)
+		if err == nil && !isPlainIdentifier(setting.name) {
+			err = newBuildError("SETTINGS", ErrInvalidSetting, fmt.Sprintf("invalid name %q", setting.name))
+		}
+
+		if err != nil {
+			ctx.addError(err)
+
+			continue
+		}
+
+		parts = append(parts, fmt.Sprintf("%s = %s", setting.name, value))
+	}
+
+	sql.WriteString(" SETTINGS ")
+	sql.WriteString(strings.Join(parts, ", "))
+}
+
+// settingLiteral renders a SETTINGS value inline, since the clause does not accept bind parameters.
+func settingLiteral(value any) (string, error) {
+	switch v := value.(type) {
+	case string:
+		return "'" + strings.ReplaceAll(strings.ReplaceAll(v, `\`, `\\`), "'", `\'`) + "'", nil
+	case bool:
+		if v {
+			return "1", nil
+		}
+
+		return "0", nil
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		return fmt.Sprintf("%d", v), nil
+	case float32, float64:
+		return fmt.Sprintf("%v", v), nil
+	default:
+		return "", newBuildError("SETTINGS", ErrInvalidSetting, fmt.Sprintf("unsupported value type %T", value))
+	}
+}
+
+func buildExpressionList(ctx *buildContext, exprs []Expression) string {
+	parts := make([]string, 0, len(exprs))
+	for _, e := range exprs {
+		parts = append(parts, e.build(ctx))
+	}
+
+	return strings.Join(parts, ", ")
+}
+
+// buildWrittenValueList renders the values of a VALUES row, accepting Default.
+func buildWrittenValueList(ctx *buildContext, values []Expression) string {
+	parts := make([]string, 0, len(values))
+	for _, v := range values {
+		parts = append(parts, buildWrittenValue(ctx, v))
+	}
+
+	return strings.Join(parts, ", ")
 }
 
 func (q *Query) buildSetSelect(sql *strings.Builder, ctx *buildContext) {
-	q.buildSelect(sql, ctx, false)
+	flat := ctx.features.SetOperations == SetOperationsCompound
+	if flat && (q.limit != nil || q.offset != nil) {
+		ctx.addError(newBuildError("LIMIT", ErrUnsupportedByDialect, "compound SELECT operands cannot be limited"))
+	}
 
-	for _, u := range q.unions {
-		sql.WriteString(" ")
+	chain := strings.Builder{}
+	q.buildSelect(&chain, ctx, false)
 
-		if u.all {
-			sql.WriteString("UNION ALL ")
-		} else {
-			sql.WriteString("UNION ")
+	// loose reports whether the chain ends with a UNION or EXCEPT that a following INTERSECT would regroup.
+	loose := false
+
+	for _, op := range q.setOps {
+		if op.op == setIntersect && loose && !flat {
+			grouped := "(" + chain.String() + ")"
+			chain.Reset()
+			chain.WriteString(grouped)
 		}
 
-		sql.WriteString(u.query.renderSetOperand(ctx))
+		loose = op.op != setIntersect
+
+		chain.WriteString(" ")
+		chain.WriteString(op.keyword(ctx))
+		chain.WriteString(" ")
+		chain.WriteString(op.query.renderSetOperand(ctx, string(op.op)))
 	}
 
+	sql.WriteString(chain.String())
 	q.appendOrdering(sql, ctx)
 
-	q.appendPagination(sql, q.setLimit, q.setOffset)
+	q.appendPagination(sql, ctx, q.setLimit, q.setOffset, len(q.orderBy) > 0)
 	q.appendLock(sql, ctx)
 }
 
@@ -973,29 +1481,77 @@ func (q *Query) appendOrdering(sql *strings.Builder, ctx *buildContext) {
 	}
 }
 
-func (q *Query) renderSetOperand(ctx *buildContext) string {
+// renderSetOperand renders a parenthesized set operation operand, including its own set operations. Dialects that
+// reject parenthesized operands (SQLite) render simple operands bare and wrap the others in SELECT * FROM (...).
+func (q *Query) renderSetOperand(ctx *buildContext, clause string) string {
 	if q == nil {
-		panic("UNION requer queries não nulas")
+		ctx.addError(newBuildError(clause, ErrNilQuery, ""))
+
+		return ""
 	}
 
 	if q.qType != queryTypeSelect {
-		panic("UNION aceita apenas queries SELECT como operando")
+		ctx.addError(newBuildError(clause, ErrInvalidClause, "operands must be SELECT queries"))
+
+		return ""
+	}
+
+	for _, err := range q.errs {
+		ctx.addError(err)
 	}
 
+	parentStrict := ctx.strict
+	ctx.strict = ctx.strict || q.strictIdentifiers
+
+	defer func() { ctx.strict = parentStrict }()
+
+	q.checkJoinConditions(ctx)
+
 	sb := strings.Builder{}
-	sb.WriteString("(")
 
 	if len(q.ctes) > 0 {
 		q.writeCTEs(&sb, ctx)
 	}
 
-	q.buildSelect(&sb, ctx, false)
-	sb.WriteString(")")
+	if len(q.setOps) > 0 {
+		q.buildSetSelect(&sb, ctx)
+	} else {
+		q.buildSelect(&sb, ctx, false)
+	}
+
+	if ctx.features.SetOperations != SetOperationsCompound {
+		return "(" + sb.String() + ")"
+	}
+
+	if len(q.ctes) > 0 || len(q.setOps) > 0 {
+		return "SELECT * FROM (" + sb.String() + ")"
+	}
+
+	if len(q.orderBy) > 0 || q.limit != nil || q.offset != nil {
+		ctx.addError(newBuildError(clause, ErrUnsupportedByDialect, "compound SELECT operands cannot be ordered or limited"))
+	}
 
 	return sb.String()
 }
 
-func (q *Query) appendPagination(sql *strings.Builder, limit *int, offset *int) {
+func (q *Query) appendPagination(sql *strings.Builder, ctx *buildContext, limit *int, offset *int, ordered bool) {
+	switch ctx.features.Limit {
+	case LimitOffsetFetch:
+		appendOffsetFetch(sql, limit, offset, ordered)
+
+		return
+	case LimitFetchFirst:
+		if offset != nil {
+			fmt.Fprintf(sql, " OFFSET %d ROWS", *offset)
+		}
+
+		if limit != nil {
+			fmt.Fprintf(sql, " FETCH FIRST %d ROWS ONLY", *limit)
+		}
+
+		return
+	}
+
 	if limit != nil {
 		fmt.Fprintf(sql, " LIMIT %d", *limit)
 	}
@@ -1005,21 +1561,88 @@ func (q *Query) appendPagination(sql *strings.Builder, limit *int, offset *int)
 	}
 }
 
+// appendOffsetFetch renders OFFSET ... ROWS FETCH NEXT ... ROWS ONLY. The syntax requires an ORDER BY, so unordered
+// queries are ordered by (SELECT NULL), which keeps the engine's natural order.
+func appendOffsetFetch(sql *strings.Builder, limit *int, offset *int, ordered bool) {
+	if limit == nil && offset == nil {
+		return
+	}
+
+	if !ordered {
+		sql.WriteString(" ORDER BY (SELECT NULL)")
+	}
+
+	skip := 0
+	if offset != nil {
+		skip = *offset
+	}
+
+	fmt.Fprintf(sql, " OFFSET %d ROWS", skip)
+
+	if limit != nil {
+		fmt.Fprintf(sql, " FETCH NEXT %d ROWS ONLY", *limit)
+	}
+}
+
 func (q *Query) appendLock(sql *strings.Builder, ctx *buildContext) {
 	if q.lock.mode == lockNone {
 		return
 	}
 
+	syntax := ctx.features.Lock
+	if !requireFeature(ctx, syntax != LockUnsupported, q.lock.mode.clause()) {
+		return
+	}
+
+	if q.lock.wait != lockWaitDefault && !requireFeature(ctx, ctx.features.LockModifiers, q.lock.wait.clause()) {
+		return
+	}
+
+	if syntax == LockTableHints {
+		return
+	}
+
 	switch q.lock.mode {
 	case lockForUpdate:
 		sql.WriteString(" FOR UPDATE")
 	case lockShare:
-		if kind, ok := dialectKindOf(ctx.dialect); ok && kind == dialectMySQL {
+		switch {
+		case syntax == LockForUpdateOnly:
+			ctx.addError(newBuildError(q.lock.mode.clause(), ErrUnsupportedByDialect, "only FOR UPDATE is available"))
+
+			return
+		case syntax == LockShareMode && q.lock.wait == lockWaitDefault:
 			sql.WriteString(" LOCK IN SHARE MODE")
-		} else {
+		default:
 			sql.WriteString(" FOR SHARE")
 		}
 	}
+
+	if q.lock.wait != lockWaitDefault {
+		sql.WriteString(" ")
+		sql.WriteString(q.lock.wait.clause())
+	}
+}
+
+// writeTableHints renders row-level locks as table hints right after the FROM table on dialects using LockTableHints.
+func (q *Query) writeTableHints(sql *strings.Builder, ctx *buildContext) {
+	if q.lock.mode == lockNone || ctx.features.Lock != LockTableHints {
+		return
+	}
+
+	hints := []string{"UPDLOCK", "ROWLOCK"}
+	if q.lock.mode == lockShare {
+		hints[0] = "HOLDLOCK"
+	}
+
+	switch q.lock.wait {
+	case lockSkipLocked:
+		hints = append(hints, "READPAST")
+	case lockNoWait:
+		hints = append(hints, "NOWAIT")
+	}
+
+	fmt.Fprintf(sql, " WITH (%s)", strings.Join(hints, ", "))
 }
 
 func (q *Query) writeOptimizerHints(sql *strings.Builder, ctx *buildContext) {
@@ -1044,27 +1667,55 @@ func (q *Query) writeOptimizerHints(sql *strings.Builder, ctx *buildContext) {
 	sql.WriteString(" */ ")
 }
 
-func (q *Query) ensureLockable() {
+func (q *Query) ensureLockable(mode lockMode) bool {
 	if q.qType != queryTypeSelect {
-		panic("row-level locks estão disponíveis apenas para consultas SELECT")
+		q.addError(newBuildError(mode.clause(), ErrInvalidClause, "row-level locks require a SELECT query"))
+
+		return false
+	}
+
+	if len(q.setOps) > 0 {
+		q.addError(newBuildError(mode.clause(), ErrInvalidClause, "row-level locks are not supported on set operations"))
+
+		return false
 	}
 
-	if len(q.unions) > 0 {
-		panic("row-level locks não são suportados em consultas UNION/UNION ALL")
+	return true
+}
+
+// addError records a configuration failure that is reported when the query is built.
+func (q *Query) addError(err *BuildError) {
+	if err.QueryType == "" {
+		err.QueryType = string(q.qType)
 	}
+
+	q.errs = append(q.errs, err)
 }
 
 func (q *Query) buildInsert(sql *strings.Builder, ctx *buildContext) {
-	insertDialect := ctx.insertDialect
-	if insertDialect == nil {
-		insertDialect = defaultInsertDialect{dialect: ctx.dialect}
+	if q.insertTable == nil {
+		ctx.addError(newBuildError("INSERT INTO", ErrMissingInsertTable, ""))
+
+		return
 	}
 
 	if q.insertIgnore && (len(q.onConflictSet) > 0 || q.onConflictDoNothing) {
-		panic("INSERT IGNORE não pode ser combinado com handlers explícitos de conflito")
+		ctx.addError(newBuildError("INSERT IGNORE", ErrConflictingClauses, "cannot be combined with explicit ON CONFLICT handlers"))
 	}
 
-	insertDialect.writeInsertKeyword(sql, q.insertIgnore)
+	if ctx.features.Upsert == UpsertMerge && (len(q.onConflictSet) > 0 || q.onConflictDoNothing) {
+		q.buildMergeUpsert(sql, ctx)
+
+		return
+	}
+
+	ignoreSyntax := ctx.features.InsertIgnore
+	if q.insertIgnore && requireFeature(ctx, ignoreSyntax != InsertIgnoreUnsupported, "INSERT IGNORE") &&
+		ignoreSyntax == InsertIgnoreKeyword {
+		sql.WriteString("INSERT IGNORE ")
+	} else {
+		sql.WriteString("INSERT ")
+	}
 
 	q.writeOptimizerHints(sql, ctx)
 
@@ -1073,27 +1724,23 @@ func (q *Query) buildInsert(sql *strings.Builder, ctx *buildContext) {
 
 	if len(q.insertCols) > 0 {
 		sql.WriteString(" (")
-		sql.WriteString(strings.Join(q.insertCols, ", "))
+		sql.WriteString(ctx.quoteIdentifierList(q.insertCols))
 		sql.WriteString(")")
 	}
 
-	valueRows := make([]string, 0, len(q.insertValues))
-
-	for _, row := range q.insertValues {
-		parts := make([]string, 0, len(row))
-		for _, v := range row {
-			parts = append(parts, v.build(ctx))
-		}
-
-		valueRows = append(valueRows, fmt.Sprintf("(%s)", strings.Join(parts, ", ")))
-	}
+	q.writeOutput(sql, ctx, "INSERTED")
 
-	if len(valueRows) > 0 {
-		sql.WriteString(" VALUES ")
-		sql.WriteString(strings.Join(valueRows, ", "))
+	switch {
+	case q.insertDefaults:
+		q.writeDefaultValues(sql, ctx)
+	case q.insertSelect != nil:
+		sql.WriteString(" ")
+		sql.WriteString(q.insertSource(ctx))
+	default:
+		q.writeInsertValues(sql, ctx)
 	}
 
-	if insertDialect.insertIgnoreUsesOnConflict(q.insertIgnore) {
+	if q.insertIgnore && ignoreSyntax == InsertIgnoreOnConflict {
 		sql.WriteString(" ON CONFLICT DO NOTHING")
 		q.writeReturning(sql, ctx)
 
@@ -1104,57 +1751,308 @@ func (q *Query) buildInsert(sql *strings.Builder, ctx *buildContext) {
 	q.writeReturning(sql, ctx)
 }
 
-func (q *Query) buildUpdate(sql *strings.Builder, ctx *buildContext) {
-	sql.WriteString("UPDATE ")
+func (q *Query) writeInsertValues(sql *strings.Builder, ctx *buildContext) {
+	valueRows := make([]string, 0, len(q.insertValues))
 
-	q.writeOptimizerHints(sql, ctx)
+	for _, row := range q.insertValues {
+		valueRows = append(valueRows, fmt.Sprintf("(%s)", buildWrittenValueList(ctx, row)))
+	}
 
-	sql.WriteString(q.updateTable.build(ctx))
+	if len(valueRows) > 0 {
+		sql.WriteString(" VALUES ")
+		sql.WriteString(strings.Join(valueRows, ", "))
+	}
+}
 
-	if len(q.setClauses) == 0 {
-		panic("UPDATE requires at least one SET clause")
+func (q *Query) writeDefaultValues(sql *strings.Builder, ctx *buildContext) {
+	if len(q.insertCols) > 0 || len(q.insertValues) > 0 || q.insertSelect != nil {
+		ctx.addError(newBuildError("DEFAULT VALUES", ErrConflictingClauses, "cannot be combined with columns, VALUES rows or SELECT"))
+	}
+
+	switch ctx.features.DefaultValues {
+	case DefaultValuesClause:
+		sql.WriteString(" DEFAULT VALUES")
+	case DefaultValuesEmptyRow:
+		sql.WriteString(" () VALUES ()")
+	default:
+		ctx.addError(newBuildError("DEFAULT VALUES", ErrUnsupportedByDialect, ""))
 	}
+}
+
+// ctesInsideInsertSelect reports whether the CTEs are rendered right before the SELECT of an INSERT ... SELECT
+// instead of ahead of the statement.
+func (q *Query) ctesInsideInsertSelect(ctx *buildContext) bool {
+	return q.qType == queryTypeInsert && q.insertSelect != nil && ctx.features.CTEInInsertSelect
+}
 
-	setParts := make([]string, 0, len(q.setClauses))
-	for _, s := range q.setClauses {
-		setParts = append(setParts, s.build(ctx))
+// insertSource renders the SELECT of an INSERT ... SELECT, preceded by the outer CTEs when the dialect expects them
+// there, merged with the CTEs of the SELECT itself.
+func (q *Query) insertSource(ctx *buildContext) string {
+	if len(q.insertValues) > 0 {
+		ctx.addError(newBuildError("INSERT ... SELECT", ErrConflictingClauses, "cannot be combined with VALUES rows"))
 	}
 
-	sql.WriteString(" SET ")
-	sql.WriteString(strings.Join(setParts, ", "))
+	source := q.insertSelect
+	if source.qType != queryTypeSelect && source.qType != queryTypeRaw {
+		ctx.addError(newBuildError("INSERT ... SELECT", ErrInvalidClause, "source must be a SELECT query"))
+	}
 
-	if q.from != nil {
-		sql.WriteString(" FROM ")
-		sql.WriteString(q.from.build(ctx))
+	hasConflictHandler := len(q.onConflictSet) > 0 || q.onConflictDoNothing ||
+		(q.insertIgnore && ctx.features.InsertIgnore == InsertIgnoreOnConflict)
+
+	if ctx.features.UpsertSelectWhere && hasConflictHandler && source.qType == queryTypeSelect &&
+		source.where == nil && len(source.setOps) == 0 {
+		withWhere := *source
+		withWhere.where = Raw("true")
+		source = &withWhere
 	}
 
-	for _, j := range q.joins {
-		sql.WriteString(" ")
-		sql.WriteString(j.build(ctx))
+	sql := strings.Builder{}
+
+	switch {
+	case !q.ctesInsideInsertSelect(ctx):
+	case source.qType == queryTypeSelect && len(source.ctes) > 0:
+		// Both lists share the single WITH in front of the SELECT; the outer CTEs come first so the source ones can
+		// read them.
+		merged := *source
+		merged.ctes = append(slices.Clone(q.ctes), source.ctes...)
+		source = &merged
+
+		for i, c := range merged.ctes {
+			if slices.ContainsFunc(merged.ctes[:i], func(other cte) bool { return other.name == c.name }) {
+				ctx.addError(newBuildError("WITH", ErrConflictingClauses,
+					fmt.Sprintf("CTE %s is declared by both the INSERT and its SELECT", c.name)))
+			}
+		}
+	default:
+		q.writeCTEs(&sql, ctx)
 	}
 
-	q.buildPredicates(sql, ctx, "WHERE", q.where)
-	q.writeReturning(sql, ctx)
+	sql.WriteString(source.render(ctx))
+
+	return sql.String()
 }
 
-func (q *Query) buildDelete(sql *strings.Builder, ctx *buildContext) {
-	sql.WriteString("DELETE ")
+func (q *Query) buildUpdate(sql *strings.Builder, ctx *buildContext) {
+	sql.WriteString("UPDATE ")
 
 	q.writeOptimizerHints(sql, ctx)
 
-	sql.WriteString("FROM ")
-	sql.WriteString(q.deleteTable.build(ctx))
-
-	q.buildPredicates(sql, ctx, "WHERE", q.where)
-	q.writeReturning(sql, ctx)
-}
+	joined := q.from != nil || len(q.joins) > 0
+	syntax := ctx.features.UpdateJoin
 
-func (q *Query) buildPredicates(sql *strings.Builder, ctx *buildContext, keyword string, pred Predicate) {
-	if pred == nil {
+	if joined && !requireFeature(ctx, syntax != UpdateJoinUnsupported, "UPDATE ... JOIN") {
 		return
 	}
 
-	clause := pred.build(ctx)
+	limited := q.limitedDML(ctx, joined)
+	q.writeDMLTop(sql, ctx, limited)
+
+	switch {
+	case joined && syntax == UpdateJoinInline:
+		q.writeTargetTables(sql, ctx, q.updateTable)
+	case joined && syntax == UpdateJoinTargetFrom:
+		sql.WriteString(ctx.quoteIdentifier(tableQualifier(q.updateTable)))
+	default:
+		sql.WriteString(q.updateTable.build(ctx))
+	}
+
+	if len(q.setClauses) == 0 {
+		ctx.addError(newBuildError("SET", ErrMissingSetClause, ""))
+	}
+
+	sql.WriteString(" SET ")
+	sql.WriteString(buildSetClauses(ctx, q.setClauses))
+	q.writeOutput(sql, ctx, "INSERTED")
+
+	where := q.where
+
+	switch {
+	case joined && syntax == UpdateJoinFrom:
+		where = q.writeSourceList(sql, ctx, "FROM")
+	case joined && syntax == UpdateJoinTargetFrom:
+		sql.WriteString(" FROM ")
+		q.writeTargetTables(sql, ctx, q.updateTable)
+	}
+
+	q.writeDMLWhere(sql, ctx, where, limited)
+	q.writeReturning(sql, ctx)
+	q.writeDMLTrailingLimit(sql, ctx, limited)
+}
+
+func (q *Query) buildDelete(sql *strings.Builder, ctx *buildContext) {
+	sql.WriteString("DELETE ")
+
+	q.writeOptimizerHints(sql, ctx)
+
+	joined := q.from != nil || len(q.joins) > 0
+	syntax := ctx.features.DeleteJoin
+
+	if joined && !requireFeature(ctx, syntax != DeleteJoinUnsupported, "DELETE ... JOIN") {
+		return
+	}
+
+	limited := q.limitedDML(ctx, joined)
+	q.writeDMLTop(sql, ctx, limited)
+
+	where := q.where
+
+	if joined && syntax == DeleteJoinTargetFrom {
+		sql.WriteString(ctx.quoteIdentifier(tableQualifier(q.deleteTable)))
+		q.writeOutput(sql, ctx, "DELETED")
+		sql.WriteString(" FROM ")
+		q.writeTargetTables(sql, ctx, q.deleteTable)
+	} else {
+		sql.WriteString("FROM ")
+		sql.WriteString(q.deleteTable.build(ctx))
+		q.writeOutput(sql, ctx, "DELETED")
+
+		if joined {
+			where = q.writeSourceList(sql, ctx, "USING")
+		}
+	}
+
+	q.writeDMLWhere(sql, ctx, where, limited)
+	q.writeReturning(sql, ctx)
+	q.writeDMLTrailingLimit(sql, ctx, limited)
+}
+
+// limitedDML reports whether OrderBy, Limit and Offset apply to the UPDATE or DELETE, recording an error when the
+// dialect cannot honor them. Multi-table statements cannot be limited on any dialect.
+func (q *Query) limitedDML(ctx *buildContext, joined bool) bool {
+	if len(q.orderBy) == 0 && q.limit == nil && q.offset == nil {
+		return false
+	}
+
+	syntax := ctx.features.LimitedDML
+	clause := string(q.qType) + " ... LIMIT"
+
+	switch {
+	case !requireFeature(ctx, syntax != LimitedDMLUnsupported, clause):
+		return false
+	case joined:
+		ctx.addError(newBuildError(clause, ErrUnsupportedByDialect, "ORDER BY and LIMIT require a single-table statement"))
+
+		return false
+	case q.offset != nil && (syntax == LimitedDMLClause || syntax == LimitedDMLTop):
+		ctx.addError(newBuildError("OFFSET", ErrUnsupportedByDialect, "not supported on "+string(q.qType)))
+
+		return false
+	case len(q.orderBy) > 0 && syntax == LimitedDMLTop:
+		ctx.addError(newBuildError("ORDER BY", ErrUnsupportedByDialect, "TOP cannot be ordered on "+string(q.qType)))
+
+		return false
+	}
+
+	return true
+}
+
+func (q *Query) writeDMLTop(sql *strings.Builder, ctx *buildContext, limited bool) {
+	if limited && ctx.features.LimitedDML == LimitedDMLTop && q.limit != nil {
+		fmt.Fprintf(sql, "TOP (%d) ", *q.limit)
+	}
+}
+
+// writeDMLWhere renders the WHERE clause of an UPDATE or DELETE followed by its ordering and limit. Dialects without
+// a native limit select the affected rows by their row identifier in a subquery instead; without Limit or Offset the
+// ordering is irrelevant and the subquery is skipped.
+func (q *Query) writeDMLWhere(sql *strings.Builder, ctx *buildContext, where Predicate, limited bool) {
+	syntax := ctx.features.LimitedDML
+
+	if limited && syntax == LimitedDMLSubquery && (q.limit != nil || q.offset != nil) {
+		target := q.updateTable
+		if q.qType == queryTypeDelete {
+			target = q.deleteTable
+		}
+
+		rowID := ctx.features.RowIdentifier
+		rows := &Query{
+			qType:         queryTypeSelect,
+			selectColumns: []Expression{Raw(rowID)},
+			from:          target,
+			where:         where,
+			orderBy:       q.orderBy,
+			limit:         q.limit,
+			offset:        q.offset,
+		}
+
+		fmt.Fprintf(sql, " WHERE %s IN (%s)", rowID, rows.render(ctx))
+
+		return
+	}
+
+	q.buildPredicates(sql, ctx, "WHERE", where)
+
+	if limited && syntax == LimitedDMLClause {
+		q.appendOrdering(sql, ctx)
+		q.appendPagination(sql, ctx, q.limit, nil, false)
+	}
+}
+
+// writeDMLTrailingLimit renders ORDER BY and LIMIT after RETURNING, as SQLite expects.
+func (q *Query) writeDMLTrailingLimit(sql *strings.Builder, ctx *buildContext, limited bool) {
+	if limited && ctx.features.LimitedDML == LimitedDMLAfterReturning {
+		q.appendOrdering(sql, ctx)
+		q.appendPagination(sql, ctx, q.limit, q.offset, false)
+	}
+}
+
+// writeTargetTables renders the target of a multi-table UPDATE or DELETE followed by the From table and the joins, as
+// MySQL and SQL Server list them.
+func (q *Query) writeTargetTables(sql *strings.Builder, ctx *buildContext, target TableExpression) {
+	sql.WriteString(target.build(ctx))
+
+	if q.from != nil {
+		sql.WriteString(", ")
+		sql.WriteString(q.from.build(ctx))
+	}
+
+	writeJoins(sql, ctx, q.joins)
+}
+
+// writeSourceList renders the FROM or USING list of a PostgreSQL-style UPDATE or DELETE and returns the WHERE
+// predicate to render. The list cannot join the target table, so without From the first inner join becomes the list
+// head and its ON condition moves to WHERE.
+func (q *Query) writeSourceList(sql *strings.Builder, ctx *buildContext, keyword string) Predicate {
+	head, joins, where := q.from, q.joins, q.where
+
+	if head == nil {
+		first := joins[0]
+		if (first.kind != "JOIN" && first.kind != "CROSS JOIN") || first.lateral || len(first.using) > 0 {
+			ctx.addError(newBuildError(keyword, ErrUnsupportedByDialect, first.kind+" on the target table requires From"))
+
+			return where
+		}
+
+		head, joins = first.table, joins[1:]
+
+		if first.on != nil {
+			where = compoundPredicate{op: "AND", parts: flattenAndPredicates(first.on, where)}
+		}
+	}
+
+	sql.WriteString(" ")
+	sql.WriteString(keyword)
+	sql.WriteString(" ")
+	sql.WriteString(head.build(ctx))
+	writeJoins(sql, ctx, joins)
+
+	return where
+}
+
+func writeJoins(sql *strings.Builder, ctx *buildContext, joins []joinClause) {
+	for _, j := range joins {
+		sql.WriteString(" ")
+		sql.WriteString(j.build(ctx))
+	}
+}
+
+func (q *Query) buildPredicates(sql *strings.Builder, ctx *buildContext, keyword string, pred Predicate) {
+	if pred == nil {
+		return
+	}
+
+	clause := pred.build(ctx)
 	if clause == "" {
 		return
 	}
@@ -1170,7 +2068,12 @@ func (q *Query) writeReturning(sql *strings.Builder, ctx *buildContext) {
 		return
 	}
 
-	if kind, ok := dialectKindOf(ctx.dialect); ok && kind == dialectMySQL && ctx.mysqlReturning == MySQLReturningOmit {
+	if ctx.features.ReturningOmittable && ctx.mysqlReturning == MySQLReturningOmit {
+		return
+	}
+
+	if !requireFeature(ctx, ctx.features.Returning != ReturningUnsupported, "RETURNING") ||
+		ctx.features.Returning == ReturningOutput {
 		return
 	}
 
@@ -1181,6 +2084,63 @@ func (q *Query) writeReturning(sql *strings.Builder, ctx *buildContext) {
 
 	sql.WriteString(" RETURNING ")
 	sql.WriteString(strings.Join(parts, ", "))
+
+	if ctx.features.Returning == ReturningClauseInto {
+		q.writeReturningInto(sql, ctx)
+	}
+}
+
+func (q *Query) writeReturningInto(sql *strings.Builder, ctx *buildContext) {
+	if len(q.returningInto) != len(q.returning) {
+		ctx.addError(newBuildError("RETURNING INTO", ErrReturningDestinations,
+			fmt.Sprintf("%d expressions, %d destinations", len(q.returning), len(q.returningInto))))
+
+		return
+	}
+
+	binds := make([]string, 0, len(q.returningInto))
+	for _, dest := range q.returningInto {
+		binds = append(binds, ctx.nextPlaceholder(dest))
+	}
+
+	sql.WriteString(" INTO ")
+	sql.WriteString(strings.Join(binds, ", "))
+}
+
+// writeOutput renders Returning as an OUTPUT clause on dialects using ReturningOutput. Plain column references are
+// read from the given pseudo-table (INSERTED or DELETED), dropping any table qualifier.
+func (q *Query) writeOutput(sql *strings.Builder, ctx *buildContext, pseudoTable string) {
+	if len(q.returning) == 0 || ctx.features.Returning != ReturningOutput {
+		return
+	}
+
+	parts := make([]string, 0, len(q.returning))
+	for _, r := range q.returning {
+		parts = append(parts, outputColumn(ctx, r, pseudoTable))
+	}
+
+	sql.WriteString(" OUTPUT ")
+	sql.WriteString(strings.Join(parts, ", "))
+}
+
+func outputColumn(ctx *buildContext, expr Expression, pseudoTable string) string {
+	switch e := expr.(type) {
+	case rawExpr:
+		if parts, ok := identifierParts(e.sql); ok && len(e.args) == 0 {
+			return pseudoTable + "." + ctx.quoteIdentifier(parts[len(parts)-1])
+		}
+	case Column:
+		if parts, ok := identifierParts(e.name); ok {
+			column := pseudoTable + "." + ctx.quoteIdentifier(parts[len(parts)-1])
+			if e.alias != "" {
+				column += " AS " + ctx.quoteIdentifier(e.alias)
+			}
+
+			return column
+		}
+	}
+
+	return expr.build(ctx)
 }
 
 func (q *Query) writeOnConflict(sql *strings.Builder, ctx *buildContext) {
@@ -1188,28 +2148,66 @@ func (q *Query) writeOnConflict(sql *strings.Builder, ctx *buildContext) {
 		return
 	}
 
-	if d, ok := ctx.dialect.(sqlDialect); ok && d.kind == dialectMySQL {
-		if len(q.onConflictSet) == 0 {
-			return
-		}
+	q.validateConflictHandler(ctx)
 
-		setParts := make([]string, 0, len(q.onConflictSet))
-		for _, s := range q.onConflictSet {
-			setParts = append(setParts, s.build(ctx))
-		}
+	switch ctx.features.Upsert {
+	case UpsertOnDuplicateKey:
+		q.writeOnDuplicateKey(sql, ctx)
+	case UpsertOnConflict:
+		q.writeOnConflictClause(sql, ctx)
+	default:
+		ctx.addError(newBuildError("ON CONFLICT", ErrUnsupportedByDialect, ""))
+	}
+}
+
+// validateConflictHandler reports conflict targets and guards that cannot be combined.
+func (q *Query) validateConflictHandler(ctx *buildContext) {
+	if q.onConflictConstraint != "" && len(q.onConflictTarget) > 0 {
+		ctx.addError(newBuildError("ON CONFLICT", ErrConflictingClauses, "constraint and column targets cannot be combined"))
+	}
+
+	if q.onConflictTargetWhere != nil && len(q.onConflictTarget) == 0 {
+		ctx.addError(newBuildError("ON CONFLICT", ErrInvalidClause, "target WHERE requires conflict target columns"))
+	}
 
-		sql.WriteString(" ON DUPLICATE KEY UPDATE ")
-		sql.WriteString(strings.Join(setParts, ", "))
+	if q.onConflictUpdateWhere != nil && q.onConflictDoNothing {
+		ctx.addError(newBuildError("ON CONFLICT", ErrInvalidClause, "update WHERE requires OnConflictDoUpdate"))
+	}
+}
 
+func (q *Query) writeOnDuplicateKey(sql *strings.Builder, ctx *buildContext) {
+	if len(q.onConflictSet) == 0 {
 		return
 	}
 
+	if q.onConflictUpdateWhere != nil {
+		ctx.addError(newBuildError("ON DUPLICATE KEY UPDATE", ErrUnsupportedByDialect, "update WHERE guards are not supported"))
+	}
+
+	if ctx.mysqlExcluded == MySQLExcludedRowAlias && q.insertSelect == nil && !q.insertDefaults {
+		sql.WriteString(" AS excluded")
+
+		ctx.rowAlias = true
+	}
+
+	sql.WriteString(" ON DUPLICATE KEY UPDATE ")
+	sql.WriteString(buildSetClauses(ctx, q.onConflictSet))
+}
+
+func (q *Query) writeOnConflictClause(sql *strings.Builder, ctx *buildContext) {
 	sql.WriteString(" ON CONFLICT")
 
-	if len(q.onConflictTarget) > 0 {
+	switch {
+	case q.onConflictConstraint != "":
+		if requireFeature(ctx, ctx.features.ConflictConstraint, "ON CONFLICT ON CONSTRAINT") {
+			sql.WriteString(" ON CONSTRAINT ")
+			sql.WriteString(ctx.quoteIdentifier(q.onConflictConstraint))
+		}
+	case len(q.onConflictTarget) > 0:
 		sql.WriteString(" (")
-		sql.WriteString(strings.Join(q.onConflictTarget, ", "))
+		sql.WriteString(ctx.quoteIdentifierList(q.onConflictTarget))
 		sql.WriteString(")")
+		q.buildPredicates(sql, ctx, "WHERE", q.onConflictTargetWhere)
 	}
 
 	if q.onConflictDoNothing {
@@ -1218,13 +2216,118 @@ func (q *Query) writeOnConflict(sql *strings.Builder, ctx *buildContext) {
 		return
 	}
 
-	setParts := make([]string, 0, len(q.onConflictSet))
-	for _, s := range q.onConflictSet {
-		setParts = append(setParts, s.build(ctx))
+	sql.WriteString(" DO UPDATE SET ")
+	sql.WriteString(buildSetClauses(ctx, q.onConflictSet))
+	q.buildPredicates(sql, ctx, "WHERE", q.onConflictUpdateWhere)
+}
+
+// buildMergeUpsert renders an INSERT with conflict handlers as MERGE INTO ... USING (SELECT ... FROM dual) excluded.
+// Rows are matched on the conflict target, so assignments written for ON CONFLICT (EXCLUDED.column) keep working.
+func (q *Query) buildMergeUpsert(sql *strings.Builder, ctx *buildContext) {
+	if len(q.onConflictTarget) == 0 {
+		ctx.addError(newBuildError("ON CONFLICT", ErrUnsupportedByDialect, "MERGE requires conflict target columns"))
 	}
 
-	sql.WriteString(" DO UPDATE SET ")
-	sql.WriteString(strings.Join(setParts, ", "))
+	if len(q.insertCols) == 0 {
+		ctx.addError(newBuildError("ON CONFLICT", ErrUnsupportedByDialect, "MERGE requires explicit insert columns"))
+	}
+
+	if len(q.returning) > 0 {
+		ctx.addError(newBuildError("RETURNING", ErrUnsupportedByDialect, "not available on MERGE upserts"))
+	}
+
+	if q.onConflictConstraint != "" || q.onConflictTargetWhere != nil {
+		ctx.addError(newBuildError("ON CONFLICT", ErrUnsupportedByDialect, "MERGE only matches on conflict target columns"))
+	}
+
+	q.validateConflictHandler(ctx)
+
+	sql.WriteString("MERGE ")
+	q.writeOptimizerHints(sql, ctx)
+	sql.WriteString("INTO ")
+	sql.WriteString(q.insertTable.build(ctx))
+
+	sql.WriteString(" USING (")
+	sql.WriteString(q.mergeUpsertSource(ctx))
+	sql.WriteString(") excluded ON (")
+
+	target := ctx.quoteIdentifier(tableQualifier(q.insertTable))
+	conditions := make([]string, 0, len(q.onConflictTarget))
+
+	for _, col := range q.onConflictTarget {
+		col = ctx.quoteIdentifier(col)
+		conditions = append(conditions, fmt.Sprintf("%s.%s = excluded.%s", target, col, col))
+	}
+
+	sql.WriteString(strings.Join(conditions, " AND "))
+	sql.WriteString(")")
+
+	if !q.onConflictDoNothing {
+		sql.WriteString(" WHEN MATCHED THEN UPDATE SET ")
+		sql.WriteString(buildSetClauses(ctx, q.onConflictSet))
+		q.buildPredicates(sql, ctx, "WHERE", q.onConflictUpdateWhere)
+	}
+
+	values := make([]string, 0, len(q.insertCols))
+	for _, col := range q.insertCols {
+		values = append(values, "excluded."+ctx.quoteIdentifier(col))
+	}
+
+	sql.WriteString(" WHEN NOT MATCHED THEN INSERT (")
+	sql.WriteString(ctx.quoteIdentifierList(q.insertCols))
+	sql.WriteString(") VALUES (")
+	sql.WriteString(strings.Join(values, ", "))
+	sql.WriteString(")")
+}
+
+// mergeUpsertSource renders the USING relation of a MERGE upsert: the INSERT ... SELECT source, or one SELECT ... FROM dual
+// per VALUES row.
+func (q *Query) mergeUpsertSource(ctx *buildContext) string {
+	if q.insertSelect != nil {
+		return q.insertSource(ctx)
+	}
+
+	rows := make([]string, 0, len(q.insertValues))
+
+	for _, row := range q.insertValues {
+		parts := make([]string, 0, len(row))
+		for i, v := range row {
+			part := v.build(ctx)
+			if i < len(q.insertCols) {
+				part = fmt.Sprintf("%s AS %s", part, ctx.quoteIdentifier(q.insertCols[i]))
+			}
+
+			parts = append(parts, part)
+		}
+
+		rows = append(rows, fmt.Sprintf("SELECT %s FROM dual", strings.Join(parts, ", ")))
+	}
+
+	return strings.Join(rows, " UNION ALL ")
+}
+
+// tableQualifier returns the name used to qualify columns of a table expression: its alias when set, otherwise the
+// table name.
+func tableQualifier(table TableExpression) string {
+	ref, ok := table.(TableRef)
+	if !ok {
+		return ""
+	}
+
+	if ref.alias != "" {
+		return ref.alias
+	}
+
+	return ref.name
+}
+
+func buildSetClauses(ctx *buildContext, clauses []SetClause) string {
+	parts := make([]string, 0, len(clauses))
+	for _, s := range clauses {
+		parts = append(parts, s.build(ctx))
+	}
+
+	return strings.Join(parts, ", ")
 }
 
 // SetClause represents a column-value assignment used in UPDATE statements.
@@ -1233,35 +2336,116 @@ type SetClause struct {
 	value  Expression
 }
 
-type unionClause struct {
+type setOperator string
+
+const (
+	setUnion     setOperator = "UNION"
+	setIntersect setOperator = "INTERSECT"
+	setExcept    setOperator = "EXCEPT"
+)
+
+type setOperation struct {
+	op    setOperator
 	query *Query
 	all   bool
 }
 
+// keyword renders the set operator, recording an error when the dialect lacks it or its ALL variant.
+func (s setOperation) keyword(ctx *buildContext) string {
+	syntax := ctx.features.SetOperations
+	keyword := string(s.op)
+
+	if s.op != setUnion {
+		requireFeature(ctx, syntax != SetOperationsUnion, keyword)
+
+		if s.op == setExcept && syntax == SetOperationsMinus {
+			keyword = "MINUS"
+		}
+	}
+
+	if !s.all {
+		return keyword
+	}
+
+	if s.op != setUnion {
+		requireFeature(ctx, syntax == SetOperationsAll, keyword+" ALL")
+	}
+
+	return keyword + " ALL"
+}
+
 // Set defines a column assignment for UPDATE queries.
 func Set(column string, value any) SetClause {
 	return SetClause{column: column, value: toValueExpression(value)}
 }
 
 func (s SetClause) build(ctx *buildContext) string {
-	return fmt.Sprintf("%s = %s", s.column, s.value.build(ctx))
+	return fmt.Sprintf("%s = %s", ctx.quoteIdentifier(s.column), buildWrittenValue(ctx, s.value))
 }
 
 // buildContext is used internally to collect placeholders and arguments.
 type buildContext struct {
 	args             []any
 	dialect          Dialect
-	insertDialect    insertDialect
+	features         DialectFeatures
 	placeholderIndex int
 	subqueryAlias    int
 	subqueryAliases  map[*Query]string
 	mysqlReturning   MySQLReturningMode
+	mysqlExcluded    MySQLExcludedMode
+	rowAlias         bool
+	quoting          IdentifierQuoting
+	strict           bool
+	strictJoins      bool
+	queryType        queryType
+	errs             []error
+}
+
+func newBuildContext(dialect Dialect, mysqlReturning MySQLReturningMode) *buildContext {
+	return &buildContext{dialect: dialect, features: dialect.Features(), mysqlReturning: mysqlReturning}
+}
+
+// addError accumulates a validation failure found while rendering, tagging BuildError values with the query type
+// and dialect being rendered.
+func (ctx *buildContext) addError(err error) {
+	if err == nil {
+		return
+	}
+
+	var buildErr *BuildError
+	if errors.As(err, &buildErr) {
+		tagged := *buildErr
+
+		if tagged.QueryType == "" {
+			tagged.QueryType = string(ctx.queryType)
+		}
+
+		if tagged.Dialect == "" {
+			tagged.Dialect = ctx.dialect.Kind()
+		}
+
+		err = &tagged
+	}
+
+	ctx.errs = append(ctx.errs, err)
+}
+
+// err returns the accumulated failures, unwrapped when only one was recorded.
+func (ctx *buildContext) err() error {
+	switch len(ctx.errs) {
+	case 0:
+		return nil
+	case 1:
+		return ctx.errs[0]
+	default:
+		return errors.Join(ctx.errs...)
+	}
 }
 
 // nextPlaceholder appends the provided argument and returns the placeholder symbol.
 func (ctx *buildContext) nextPlaceholder(arg any) string {
 	ctx.placeholderIndex++
-	pl := ctx.dialect.placeholder(ctx.placeholderIndex)
+	pl := ctx.dialect.Placeholder(ctx.placeholderIndex)
 	ctx.args = append(ctx.args, arg)
 
 	return pl
@@ -1294,11 +2478,11 @@ type cte struct {
 func (c cte) build(ctx *buildContext) string {
 	sb := strings.Builder{}
 
-	sb.WriteString(c.name)
+	sb.WriteString(ctx.quoteIdentifier(c.name))
 
 	if len(c.columns) > 0 {
 		sb.WriteString(" (")
-		sb.WriteString(strings.Join(c.columns, ", "))
+		sb.WriteString(ctx.quoteIdentifierList(c.columns))
 		sb.WriteString(")")
 	}
 
@@ -1311,12 +2495,38 @@ func (c cte) build(ctx *buildContext) string {
 
 // joinClause represents a SQL JOIN clause.
 type joinClause struct {
-	kind  string
-	table TableExpression
-	on    Predicate
+	kind    string
+	table   TableExpression
+	on      Predicate
+	using   []string
+	lateral bool
+}
+
+// checkJoinConditions reports joins without ON or USING conditions when strict joins are enabled for the statement
+// being rendered or for the query itself.
+func (q *Query) checkJoinConditions(ctx *buildContext) {
+	if !ctx.strictJoins && !q.strictJoins {
+		return
+	}
+
+	for _, j := range q.joins {
+		if j.on != nil || len(j.using) > 0 || j.lateral || j.kind == "CROSS JOIN" || j.kind == "NATURAL JOIN" {
+			continue
+		}
+
+		ctx.addError(newBuildError(j.kind, ErrMissingJoinCondition, "use CrossJoin for intentional cartesian products"))
+	}
 }
 
 func (j joinClause) build(ctx *buildContext) string {
+	if j.lateral {
+		return j.buildLateral(ctx)
+	}
+
+	if j.kind == "NATURAL JOIN" {
+		requireFeature(ctx, ctx.features.NaturalJoin, j.kind)
+	}
+
 	sb := strings.Builder{}
 	sb.WriteString(j.kind)
 	sb.WriteString(" ")
@@ -1327,9 +2537,45 @@ func (j joinClause) build(ctx *buildContext) string {
 		sb.WriteString(j.on.build(ctx))
 	}
 
+	if len(j.using) > 0 && requireFeature(ctx, ctx.features.JoinUsing, j.kind+" ... USING") {
+		sb.WriteString(" USING (")
+		sb.WriteString(ctx.quoteIdentifierList(j.using))
+		sb.WriteString(")")
+	}
+
 	return sb.String()
 }
 
+func (j joinClause) buildLateral(ctx *buildContext) string {
+	switch ctx.features.Lateral {
+	case LateralJoin:
+		sql := j.kind + " LATERAL " + j.table.build(ctx)
+
+		switch {
+		case j.on != nil:
+			return sql + " ON " + j.on.build(ctx)
+		case j.kind == "CROSS JOIN":
+			return sql
+		default:
+			return sql + " ON true"
+		}
+	case LateralApply:
+		if j.on != nil {
+			ctx.addError(newBuildError(j.kind+" LATERAL", ErrUnsupportedByDialect, "APPLY joins cannot have ON predicates"))
+		}
+
+		if j.kind == "LEFT JOIN" {
+			return "OUTER APPLY " + j.table.build(ctx)
+		}
+
+		return "CROSS APPLY " + j.table.build(ctx)
+	default:
+		requireFeature(ctx, false, j.kind+" LATERAL")
+
+		return ""
+	}
+}
+
 // TableExpression represents a FROM or JOIN target.
 type TableExpression interface {
 	build(*buildContext) string
@@ -1342,9 +2588,11 @@ func WithOrdinality(table any, alias string, columns ...string) TableExpression
 
 // TableRef references a table, optionally with alias or derived subquery.
 type TableRef struct {
-	name  string
-	alias string
-	sub   *Query
+	name   string
+	alias  string
+	sub    *Query
+	final  bool
+	sample float64
 }
 
 // TableAlias returns a table reference with an alias.
@@ -1370,17 +2618,53 @@ func (t TableRef) build(ctx *buildContext) string {
 			alias = ctx.nextSubqueryAlias(t.sub)
 		}
 	} else {
-		sb.WriteString(t.name)
+		sb.WriteString(ctx.quoteIdentifier(t.name))
 	}
 
 	if alias != "" {
-		sb.WriteString(" AS ")
-		sb.WriteString(alias)
+		writeTableAlias(&sb, ctx, alias)
+	}
+
+	if (t.final || t.sample > 0) && requireFeature(ctx, ctx.features.TableModifiers, "FINAL/SAMPLE") {
+		if t.final {
+			sb.WriteString(" FINAL")
+		}
+
+		if t.sample > 0 {
+			sb.WriteString(" SAMPLE ")
+			sb.WriteString(strconv.FormatFloat(t.sample, 'f', -1, 64))
+		}
 	}
 
 	return sb.String()
 }
 
+// Final reads the table with the FINAL modifier, merging parts before returning rows (ClickHouse).
+func (t TableRef) Final() TableRef {
+	t.final = true
+
+	return t
+}
+
+// Sample reads a sample of the table (ClickHouse SAMPLE). Values up to 1 are a ratio of the data; larger values are
+// an approximate number of rows.
+func (t TableRef) Sample(ratio float64) TableRef {
+	t.sample = ratio
+
+	return t
+}
+
+// writeTableAlias renders a table alias, introduced with AS on dialects that accept the keyword.
+func writeTableAlias(sb *strings.Builder, ctx *buildContext, alias string) {
+	if ctx.features.TableAliasAs {
+		sb.WriteString(" AS ")
+	} else {
+		sb.WriteString(" ")
+	}
+
+	sb.WriteString(ctx.quoteIdentifier(alias))
+}
+
 // FuncTable renders a set-returning function to be used in FROM/JOIN.
 type functionTable struct {
 	name    string
@@ -1408,19 +2692,20 @@ func (f functionTable) build(ctx *buildContext) string {
 		params = append(params, a.build(ctx))
 	}
 
-	base := fmt.Sprintf("%s(%s)", f.name, strings.Join(params, ", "))
+	sb := strings.Builder{}
+	fmt.Fprintf(&sb, "%s(%s)", f.name, strings.Join(params, ", "))
 
 	if f.alias == "" {
-		return base
+		return sb.String()
 	}
 
-	alias := fmt.Sprintf("%s AS %s", base, f.alias)
+	writeTableAlias(&sb, ctx, f.alias)
 
 	if len(f.columns) > 0 {
-		alias = fmt.Sprintf("%s (%s)", alias, strings.Join(f.columns, ", "))
+		fmt.Fprintf(&sb, " (%s)", ctx.quoteIdentifierList(f.columns))
 	}
 
-	return alias
+	return sb.String()
 }
 
 type ordinalityTable struct {
@@ -1430,19 +2715,20 @@ type ordinalityTable struct {
 }
 
 func (o ordinalityTable) build(ctx *buildContext) string {
-	requireDialect(ctx, dialectPostgres, "WITH ORDINALITY")
+	if !requireFeature(ctx, ctx.features.WithOrdinality, "WITH ORDINALITY") {
+		return ""
+	}
 
 	sb := strings.Builder{}
 	sb.WriteString(o.source.build(ctx))
 	sb.WriteString(" WITH ORDINALITY")
 
 	if o.alias != "" {
-		sb.WriteString(" AS ")
-		sb.WriteString(o.alias)
+		writeTableAlias(&sb, ctx, o.alias)
 
 		if len(o.columns) > 0 {
 			sb.WriteString(" (")
-			sb.WriteString(strings.Join(o.columns, ", "))
+			sb.WriteString(ctx.quoteIdentifierList(o.columns))
 			sb.WriteString(")")
 		}
 	}
@@ -1461,7 +2747,7 @@ func toTableExpression(value any) TableExpression {
 	case string:
 		return TableRef{name: v}
 	default:
-		panic(fmt.Sprintf("unsupported table expression: %T", value))
+		return invalidExpr{err: newBuildError("FROM", ErrUnsupportedTable, fmt.Sprintf("%T", value))}
 	}
 }
 
@@ -1505,7 +2791,7 @@ func CastAsAny[T any](values []T) []any {
 func toSQLExpression(value any) Expression {
 	switch v := value.(type) {
 	case string:
-		return rawExpr{sql: v}
+		return rawExpr{sql: v, implicit: true}
 	default:
 		return toValueExpression(v)
 	}
@@ -11,7 +11,10 @@ import (
 func assertBuild(t *testing.T, q *Query, wantSQL string, wantArgs []any) {
 	t.Helper()
 
-	gotSQL, gotArgs := q.Build()
+	gotSQL, gotArgs, err := q.BuildContext(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected build error: %v", err)
+	}
 
 	if gotSQL != wantSQL {
 		t.Fatalf("unexpected SQL.\nwant: %s\n got: %s", wantSQL, gotSQL)
@@ -264,6 +267,138 @@ func TestJoinGroupHaving(t *testing.T) {
 	)
 }
 
+func TestCrossAndNaturalJoins(t *testing.T) {
+	assertBuild(t,
+		New().Select("s.size", "c.color").From(TableAlias("sizes", "s")).CrossJoin(TableAlias("colors", "c")),
+		"SELECT s.size, c.color FROM sizes AS s CROSS JOIN colors AS c",
+		nil,
+	)
+
+	assertBuild(t,
+		New().WithDialect(DialectPostgres).Select("*").From("orders").NaturalJoin("customers"),
+		"SELECT * FROM orders NATURAL JOIN customers",
+		nil,
+	)
+
+	assertBuildError(t, New().WithDialect(DialectSQLServer).Select("*").From("orders").NaturalJoin("customers"), ErrUnsupportedByDialect)
+}
+
+func TestJoinUsing(t *testing.T) {
+	q := New().
+		WithDialect(DialectPostgres).
+		Select("order_id", "tenant_id", "c.name").
+		From("orders").
+		JoinUsing(TableAlias("customers", "c"), "tenant_id", "customer_id").
+		LeftJoinUsing("shipments", "order_id").
+		Where(Col("c.active").Eq(true))
+
+	assertBuild(t, q,
+		"SELECT order_id, tenant_id, c.name FROM orders JOIN customers AS c USING (tenant_id, customer_id) LEFT JOIN shipments USING (order_id) WHERE (c.active = $1)",
+		[]any{true},
+	)
+
+	assertBuild(t, New().Select("*").From("a").WithIdentifierQuoting(IdentifierQuotingAll).FullJoinUsing("b", "id"),
+		"SELECT * FROM `a` FULL JOIN `b` USING (`id`)",
+		nil,
+	)
+
+	assertBuildError(t, New().WithDialect(DialectSQLServer).Select("*").From("a").RightJoinUsing("b", "id"), ErrUnsupportedByDialect)
+	assertBuildError(t, New().Select("*").From("a").JoinUsing("b"), ErrInvalidClause)
+	assertBuildError(t, New().Select("*").From("a").LeftJoinUsing("b", []string{}...), ErrInvalidClause)
+}
+
+func TestStrictJoins(t *testing.T) {
+	assertBuildError(t, New().WithStrictJoins().Select("*").From("orders").Join("customers"), ErrMissingJoinCondition)
+	assertBuildError(t,
+		New().Select("*").From("orders").Where(Col("id").In(New().WithStrictJoins().Select("order_id").From("items").LeftJoin("products"))),
+		ErrMissingJoinCondition,
+	)
+	assertBuildError(t,
+		New().Select("id").From("x").Where(Exists(New().WithStrictJoins().Select("id").From("users").Join("orders"))),
+		ErrMissingJoinCondition,
+	)
+	assertBuildError(t,
+		New().WithStrictJoins().Select("*").From("orders").Where(Col("id").In(New().Select("order_id").From("items").LeftJoin("products"))),
+		ErrMissingJoinCondition,
+	)
+	assertBuildError(t,
+		New().WithStrictJoins().With("recent", New().Select("*").From("orders").Join("customers")).Select("*").From("recent"),
+		ErrMissingJoinCondition,
+	)
+	assertBuildError(t,
+		New().WithStrictJoins().Select("id").From("orders").Union(New().Select("id").From("archive").Join("customers")),
+		ErrMissingJoinCondition,
+	)
+
+	allowed := New().
+		WithStrictJoins().
+		Select("*").
+		From("orders").
+		Join("customers", Col("customers.id").Eq(Col("orders.customer_id"))).
+		JoinUsing("shipments", "order_id").
+		CrossJoin("regions").
+		NaturalJoin("tenants")
+
+	assertBuild(t, allowed,
+		"SELECT * FROM orders JOIN customers ON (customers.id = orders.customer_id) JOIN shipments USING (order_id) CROSS JOIN regions NATURAL JOIN tenants",
+		nil,
+	)
+
+	SetDefaultStrictJoins(true)
+	t.Cleanup(func() { SetDefaultStrictJoins(false) })
+
+	if !DefaultStrictJoins() {
+		t.Fatalf("expected strict joins to be enabled globally")
+	}
+
+	assertBuildError(t, New().Select("*").From("orders").Join("customers"), ErrMissingJoinCondition)
+}
+
+func TestLateralJoins(t *testing.T) {
+	latest := func(d Dialect) *Query {
+		posts := New().
+			Select("p.id", "p.title").
+			From(TableAlias("posts", "p")).
+			Where(Col("p.user_id").Eq(Col("u.id")), Col("p.published").Eq(true)).
+			OrderBy("p.created_at DESC").
+			Limit(3)
+
+		return New().
+			WithDialect(d).
+			Select("u.id", "lp.title").
+			From(TableAlias("users", "u")).
+			LeftJoinLateral(FromSubquery(posts, "lp"))
+	}
+
+	assertBuild(t, latest(DialectPostgres),
+		"SELECT u.id, lp.title FROM users AS u LEFT JOIN LATERAL (SELECT p.id, p.title FROM posts AS p WHERE (p.user_id = u.id AND p.published = $1) ORDER BY p.created_at DESC LIMIT 3) AS lp ON true",
+		[]any{true},
+	)
+
+	assertBuild(t, latest(DialectSQLServer),
+		"SELECT u.id, lp.title FROM users AS u OUTER APPLY (SELECT p.id, p.title FROM posts AS p WHERE (p.user_id = u.id AND p.published = @p1) ORDER BY p.created_at DESC OFFSET 0 ROWS FETCH NEXT 3 ROWS ONLY) AS lp",
+		[]any{true},
+	)
+
+	totals := FromSubquery(New().Select(ColAlias("SUM(o.total)", "spent")).From(TableAlias("orders", "o")).Where(Col("o.user_id").Eq(Col("u.id"))), "t")
+
+	assertBuild(t, New().Select("u.id", "t.spent").From(TableAlias("users", "u")).CrossApply(totals),
+		"SELECT u.id, t.spent FROM users AS u CROSS JOIN LATERAL (SELECT SUM(o.total) AS spent FROM orders AS o WHERE (o.user_id = u.id)) AS t",
+		nil,
+	)
+
+	assertBuild(t, New().Select("u.id", "t.spent").From(TableAlias("users", "u")).JoinLateral(totals, Col("t.spent").Gt(100)),
+		"SELECT u.id, t.spent FROM users AS u JOIN LATERAL (SELECT SUM(o.total) AS spent FROM orders AS o WHERE (o.user_id = u.id)) AS t ON (t.spent > ?)",
+		[]any{100},
+	)
+
+	assertBuildError(t, latest(DialectSQLite), ErrUnsupportedByDialect)
+	assertBuildError(t,
+		New().WithDialect(DialectOracle).Select("u.id").From(TableAlias("users", "u")).JoinLateral(totals, Col("t.spent").Gt(100)),
+		ErrUnsupportedByDialect,
+	)
+}
+
 func TestRowLevelLocks(t *testing.T) {
 	lockMySQL := New().
 		Select("id").
@@ -314,16 +449,11 @@ func TestOptimizerHints(t *testing.T) {
 	)
 }
 
-func TestRowLevelLockPanics(t *testing.T) {
-	t.Parallel()
-
-	defer func() {
-		if r := recover(); r == nil {
-			t.Fatalf("expected panic when using lock on non-select")
-		}
-	}()
-
-	New().Update("users").Set(Set("name", "x")).ForUpdate()
+func TestRowLevelLockErrors(t *testing.T) {
+	assertBuildError(t,
+		New().Update("users").Set(Set("name", "x")).ForUpdate(),
+		ErrInvalidClause,
+	)
 }
 
 func TestBetweenPredicates(t *testing.T) {
@@ -367,6 +497,16 @@ func TestMySQLReturningOmitted(t *testing.T) {
 		"UPDATE users SET name = ? WHERE (id = ?)",
 		[]any{"Ana", 1},
 	)
+
+	assertBuild(t, q.WithDialect(mariaDialect{DialectMySQL}),
+		"UPDATE users SET name = ? WHERE (id = ?)",
+		[]any{"Ana", 1},
+	)
+
+	assertBuild(t, q.WithDialect(DialectPostgres),
+		"UPDATE users SET name = $1 WHERE (id = $2) RETURNING id",
+		[]any{"Ana", 1},
+	)
 }
 
 func TestUpdateWithSubquery(t *testing.T) {
@@ -387,11 +527,126 @@ func TestUpdateWithSubquery(t *testing.T) {
 		Returning("s.job_id")
 
 	assertBuild(t, q,
-		"UPDATE job.search AS s SET updated_at = now() FROM (SELECT r.job_id FROM job.reports AS r WHERE (r.job_id = ?)) AS subq_1 WHERE (s.job_id = ? AND s.job_id = subq_1.job_id) RETURNING s.job_id",
+		"UPDATE job.search AS s, (SELECT r.job_id FROM job.reports AS r WHERE (r.job_id = ?)) AS subq_1 SET updated_at = now() WHERE (s.job_id = ? AND s.job_id = subq_1.job_id) RETURNING s.job_id",
 		[]any{jobID, jobID},
 	)
 }
 
+func TestJoinedUpdateAndDelete(t *testing.T) {
+	update := func(d Dialect) *Query {
+		return New().
+			WithDialect(d).
+			Update(TableAlias("orders", "o")).
+			Join(TableAlias("customers", "c"), Col("c.id").Eq(Col("o.customer_id"))).
+			Set(Set("status", "vip")).
+			Where(Col("c.tier").Eq("gold"))
+	}
+
+	assertBuild(t, update(DialectMySQL),
+		"UPDATE orders AS o JOIN customers AS c ON (c.id = o.customer_id) SET status = ? WHERE (c.tier = ?)",
+		[]any{"vip", "gold"},
+	)
+
+	assertBuild(t, update(DialectPostgres),
+		"UPDATE orders AS o SET status = $1 FROM customers AS c WHERE (c.id = o.customer_id AND c.tier = $2)",
+		[]any{"vip", "gold"},
+	)
+
+	assertBuild(t, update(DialectSQLServer),
+		"UPDATE o SET status = @p1 FROM orders AS o JOIN customers AS c ON (c.id = o.customer_id) WHERE (c.tier = @p2)",
+		[]any{"vip", "gold"},
+	)
+
+	remove := func(d Dialect) *Query {
+		return New().
+			WithDialect(d).
+			DeleteFrom(TableAlias("sessions", "s")).
+			Join(TableAlias("users", "u"), Col("u.id").Eq(Col("s.user_id"))).
+			LeftJoin(TableAlias("bans", "b"), Col("b.user_id").Eq(Col("u.id"))).
+			Where(Col("u.active").Eq(false))
+	}
+
+	assertBuild(t, remove(DialectMySQL),
+		"DELETE s FROM sessions AS s JOIN users AS u ON (u.id = s.user_id) LEFT JOIN bans AS b ON (b.user_id = u.id) WHERE (u.active = ?)",
+		[]any{false},
+	)
+
+	assertBuild(t, remove(DialectPostgres).Returning("s.id"),
+		"DELETE FROM sessions AS s USING users AS u LEFT JOIN bans AS b ON (b.user_id = u.id) WHERE (u.id = s.user_id AND u.active = $1) RETURNING s.id",
+		[]any{false},
+	)
+
+	assertBuild(t, remove(DialectSQLServer).Returning("s.id"),
+		"DELETE s OUTPUT DELETED.id FROM sessions AS s JOIN users AS u ON (u.id = s.user_id) LEFT JOIN bans AS b ON (b.user_id = u.id) WHERE (u.active = @p1)",
+		[]any{false},
+	)
+
+	assertBuild(t, New().WithDialect(DialectPostgres).DeleteFrom("sessions").From("users").Where(Col("users.id").Eq(Col("sessions.user_id"))),
+		"DELETE FROM sessions USING users WHERE (users.id = sessions.user_id)",
+		nil,
+	)
+
+	assertBuildError(t, remove(DialectSQLite), ErrUnsupportedByDialect)
+	assertBuildError(t, update(DialectOracle), ErrUnsupportedByDialect)
+	assertBuildError(t,
+		New().WithDialect(DialectPostgres).Update("orders").LeftJoin("customers", Raw("true")).Set(Set("status", "vip")),
+		ErrUnsupportedByDialect,
+	)
+}
+
+func TestLimitedUpdateAndDelete(t *testing.T) {
+	purge := func(d Dialect) *Query {
+		return New().
+			WithDialect(d).
+			DeleteFrom("jobs").
+			Where(Col("status").Eq("done")).
+			OrderBy("finished_at").
+			Limit(500)
+	}
+
+	assertBuild(t, purge(DialectMySQL),
+		"DELETE FROM jobs WHERE (status = ?) ORDER BY finished_at LIMIT 500",
+		[]any{"done"},
+	)
+
+	assertBuild(t, purge(DialectSQLite).Returning("id"),
+		"DELETE FROM jobs WHERE (status = ?) RETURNING id ORDER BY finished_at LIMIT 500",
+		[]any{"done"},
+	)
+
+	assertBuild(t, purge(DialectPostgres),
+		"DELETE FROM jobs WHERE ctid IN (SELECT ctid FROM jobs WHERE (status = $1) ORDER BY finished_at LIMIT 500)",
+		[]any{"done"},
+	)
+
+	assertBuild(t, purge(DialectOracle),
+		"DELETE FROM jobs WHERE ROWID IN (SELECT ROWID FROM jobs WHERE (status = :1) ORDER BY finished_at FETCH FIRST 500 ROWS ONLY)",
+		[]any{"done"},
+	)
+
+	assertBuild(t,
+		New().WithDialect(DialectPostgres).Update("jobs").Set(Set("status", "archived")).Where(Col("status").Eq("done")).Limit(100),
+		"UPDATE jobs SET status = $1 WHERE ctid IN (SELECT ctid FROM jobs WHERE (status = $2) LIMIT 100)",
+		[]any{"archived", "done"},
+	)
+
+	assertBuild(t,
+		New().WithDialect(DialectSQLServer).Update("jobs").Set(Set("status", "archived")).Where(Col("status").Eq("done")).Limit(100),
+		"UPDATE TOP (100) jobs SET status = @p1 WHERE (status = @p2)",
+		[]any{"archived", "done"},
+	)
+
+	assertBuild(t, New().WithDialect(DialectMySQL).Update("jobs").Set(Set("attempts", 0)).OrderBy("id DESC").Limit(10),
+		"UPDATE jobs SET attempts = ? ORDER BY id DESC LIMIT 10",
+		[]any{0},
+	)
+
+	assertBuildError(t, purge(DialectSQLServer), ErrUnsupportedByDialect)
+	assertBuildError(t, purge(DialectClickHouse), ErrUnsupportedByDialect)
+	assertBuildError(t, purge(DialectMySQL).Offset(10), ErrUnsupportedByDialect)
+	assertBuildError(t, purge(DialectMySQL).Join("users", Col("users.id").Eq(Col("jobs.user_id"))), ErrUnsupportedByDialect)
+}
+
 func TestDeleteWithCTE(t *testing.T) {
 	cte := New().
 		Select("id").
@@ -554,14 +809,16 @@ func TestDefaultTextSearchConfig(t *testing.T) {
 	)
 }
 
-func TestDialectSpecificFullTextSearchPanics(t *testing.T) {
-	assertPanicsWith(t, func() {
-		New().Select(TsVector("title").PlainQuery("oops")).From("posts").Build()
-	}, "Full Text Search (tsvector) é suportado apenas no dialeto postgres")
+func TestDialectSpecificFullTextSearchErrors(t *testing.T) {
+	assertBuildError(t,
+		New().Select(TsVector("title").PlainQuery("oops")).From("posts"),
+		ErrUnsupportedByDialect,
+	)
 
-	assertPanicsWith(t, func() {
-		New().WithDialect(DialectPostgres).Select(Match("title").Against("oops")).From("posts").Build()
-	}, "MATCH ... AGAINST é suportado apenas no dialeto mysql")
+	assertBuildError(t,
+		New().WithDialect(DialectPostgres).Select(Match("title").Against("oops")).From("posts"),
+		ErrUnsupportedByDialect,
+	)
 }
 
 func TestPostgresPlaceholdersAndSubqueries(t *testing.T) {
@@ -680,6 +937,91 @@ func TestUnionAllKeepsPaginationPerOperand(t *testing.T) {
 	)
 }
 
+func TestIntersectAndExcept(t *testing.T) {
+	customers := New().Select("email").From("customers")
+	subscribers := New().Select("email").From("subscribers")
+	unsubscribed := New().Select("email").From("unsubscribed").Where(Col("reason").Eq("spam"))
+
+	mixed := func(d Dialect) *Query {
+		return New().
+			WithDialect(d).
+			Select("email").From("leads").
+			Except(unsubscribed).
+			Intersect(customers).
+			Union(subscribers).
+			OrderBy("email").
+			Limit(10)
+	}
+
+	assertBuild(t, mixed(DialectPostgres),
+		"(SELECT email FROM leads EXCEPT (SELECT email FROM unsubscribed WHERE (reason = $1))) INTERSECT (SELECT email FROM customers) "+
+			"UNION (SELECT email FROM subscribers) ORDER BY email LIMIT 10",
+		[]any{"spam"},
+	)
+
+	assertBuild(t, mixed(DialectOracle),
+		"(SELECT email FROM leads MINUS (SELECT email FROM unsubscribed WHERE (reason = :1))) INTERSECT (SELECT email FROM customers) "+
+			"UNION (SELECT email FROM subscribers) ORDER BY email FETCH FIRST 10 ROWS ONLY",
+		[]any{"spam"},
+	)
+
+	assertBuild(t, mixed(DialectSQLite),
+		"SELECT email FROM leads EXCEPT SELECT email FROM unsubscribed WHERE (reason = ?) INTERSECT SELECT email FROM customers "+
+			"UNION SELECT email FROM subscribers ORDER BY email LIMIT 10",
+		[]any{"spam"},
+	)
+
+	nested := New().WithDialect(DialectSQLite).
+		Select("email").From("leads").
+		Except(New().Select("email").From("customers").UnionAll(subscribers))
+
+	assertBuild(t, nested,
+		"SELECT email FROM leads EXCEPT SELECT * FROM (SELECT email FROM customers UNION ALL SELECT email FROM subscribers)",
+		nil,
+	)
+
+	assertBuild(t, New().WithDialect(DialectPostgres).Select("email").From("leads").IntersectAll(customers).ExceptAll(subscribers),
+		"SELECT email FROM leads INTERSECT ALL (SELECT email FROM customers) EXCEPT ALL (SELECT email FROM subscribers)",
+		nil,
+	)
+
+	assertBuildError(t, New().WithDialect(DialectSQLServer).Select("email").From("leads").ExceptAll(customers), ErrUnsupportedByDialect)
+	assertBuildError(t, New().WithDialect(colonDialect{}).Select("email").From("leads").Intersect(customers), ErrUnsupportedByDialect)
+	assertBuildError(t,
+		New().WithDialect(DialectSQLite).Select("email").From("leads").Union(New().Select("email").From("customers").Limit(5)),
+		ErrUnsupportedByDialect,
+	)
+	assertBuildError(t, New().InsertInto("leads").Intersect(customers), ErrInvalidClause)
+}
+
+func TestMySQLSetOperationsMode(t *testing.T) {
+	customers := New().Select("email").From("customers")
+
+	assertBuildError(t, New().WithDialect(DialectMySQL).Select("email").From("leads").Intersect(customers), ErrUnsupportedByDialect)
+	assertBuild(t, New().WithDialect(DialectMySQL).Select("email").From("leads").UnionAll(customers),
+		"SELECT email FROM leads UNION ALL (SELECT email FROM customers)",
+		nil,
+	)
+
+	assertBuild(t,
+		New().WithDialect(DialectMySQL).WithMySQLSetOperationsMode(MySQLSetOperationsAll).Select("email").From("leads").ExceptAll(customers),
+		"SELECT email FROM leads EXCEPT ALL (SELECT email FROM customers)",
+		nil,
+	)
+
+	t.Cleanup(func() { SetDefaultMySQLSetOperationsMode(MySQLSetOperationsUnion) })
+	SetDefaultMySQLSetOperationsMode(MySQLSetOperationsAll)
+
+	if DefaultMySQLSetOperationsMode() != MySQLSetOperationsAll {
+		t.Fatalf("expected INTERSECT and EXCEPT to be enabled globally for MySQL")
+	}
+
+	assertBuild(t, New().WithDialect(DialectMySQL).Select("email").From("leads").Intersect(customers),
+		"SELECT email FROM leads INTERSECT (SELECT email FROM customers)",
+		nil,
+	)
+}
+
 func TestKeysetPaginationHelpers(t *testing.T) {
 	q := New().
 		Select("id", "created_at").
@@ -730,14 +1072,16 @@ func TestKeysetPaginationWithRawOrderings(t *testing.T) {
 	)
 }
 
-func TestKeysetPaginationPanics(t *testing.T) {
-	assertPanicsWith(t, func() {
-		New().Select("id").From("items").KeysetAfter(1)
-	}, "KeysetAfter requer ORDER BY configurado")
+func TestKeysetPaginationErrors(t *testing.T) {
+	assertBuildError(t,
+		New().Select("id").From("items").KeysetAfter(1),
+		ErrInvalidCursor,
+	)
 
-	assertPanicsWith(t, func() {
-		KeysetAfter([]Expression{Col("id").Asc()}, 1, 2)
-	}, "a quantidade de valores de cursor deve corresponder ao ORDER BY configurado")
+	assertBuildError(t,
+		New().Select("id").From("items").Where(KeysetAfter([]Expression{Col("id").Asc()}, 1, 2)),
+		ErrInvalidCursor,
+	)
 }
 
 func TestOnConflictMySQL(t *testing.T) {
@@ -779,6 +1123,85 @@ func TestOnConflictDoNothingPostgres(t *testing.T) {
 	)
 }
 
+func TestOnConflictTargetsAndGuards(t *testing.T) {
+	upsert := func(d Dialect) *Query {
+		return New().
+			WithDialect(d).
+			InsertInto("users", "email", "name", "version").
+			Values("a@example.com", "Jane", 2).
+			OnConflictDoUpdate([]string{"email"}, Set("name", Excluded("name")), Set("version", Excluded("version"))).
+			OnConflictTargetWhere(Col("deleted_at").IsNull()).
+			OnConflictUpdateWhere(Col("version").Lt(Excluded("version")))
+	}
+
+	assertBuild(t, upsert(DialectPostgres),
+		"INSERT INTO users (email, name, version) VALUES ($1, $2, $3) ON CONFLICT (email) WHERE (deleted_at IS NULL) "+
+			"DO UPDATE SET name = EXCLUDED.name, version = EXCLUDED.version WHERE (version < EXCLUDED.version)",
+		[]any{"a@example.com", "Jane", 2},
+	)
+
+	assertBuild(t, upsert(DialectSQLite),
+		"INSERT INTO users (email, name, version) VALUES (?, ?, ?) ON CONFLICT (email) WHERE (deleted_at IS NULL) "+
+			"DO UPDATE SET name = EXCLUDED.name, version = EXCLUDED.version WHERE (version < EXCLUDED.version)",
+		[]any{"a@example.com", "Jane", 2},
+	)
+
+	constraint := New().
+		WithDialect(DialectPostgres).
+		InsertInto("users", "email").
+		Values("a@example.com").
+		OnConflictConstraint("users_email_key").
+		OnConflictDoNothing()
+
+	assertBuild(t, constraint,
+		"INSERT INTO users (email) VALUES ($1) ON CONFLICT ON CONSTRAINT users_email_key DO NOTHING",
+		[]any{"a@example.com"},
+	)
+
+	assertBuildError(t, upsert(DialectMySQL), ErrUnsupportedByDialect)
+	assertBuildError(t, constraint.WithDialect(DialectSQLite), ErrUnsupportedByDialect)
+	assertBuildError(t, New().WithDialect(DialectPostgres).InsertInto("users", "email").Values("a").
+		OnConflictConstraint("users_email_key").OnConflictDoNothing("email"), ErrConflictingClauses)
+	assertBuildError(t, New().WithDialect(DialectPostgres).InsertInto("users", "email").Values("a").
+		OnConflictDoNothing().OnConflictTargetWhere(Col("deleted_at").IsNull()), ErrInvalidClause)
+	assertBuildError(t, New().WithDialect(DialectPostgres).InsertInto("users", "email").Values("a").
+		OnConflictDoNothing("email").OnConflictUpdateWhere(Col("version").Lt(1)), ErrInvalidClause)
+}
+
+func TestExcludedOnMySQL(t *testing.T) {
+	q := func() *Query {
+		return New().
+			InsertInto("users", "email", "name").
+			Values("a@example.com", "Jane").
+			OnConflictDoUpdate([]string{"email"}, Set("name", Excluded("name")))
+	}
+
+	assertBuild(t, q(),
+		"INSERT INTO users (email, name) VALUES (?, ?) ON DUPLICATE KEY UPDATE name = VALUES(name)",
+		[]any{"a@example.com", "Jane"},
+	)
+
+	assertBuild(t, q().WithMySQLExcludedMode(MySQLExcludedRowAlias),
+		"INSERT INTO users (email, name) VALUES (?, ?) AS excluded ON DUPLICATE KEY UPDATE name = excluded.name",
+		[]any{"a@example.com", "Jane"},
+	)
+
+	t.Cleanup(func() { SetDefaultMySQLExcludedMode(MySQLExcludedValues) })
+	SetDefaultMySQLExcludedMode(MySQLExcludedRowAlias)
+
+	fromSelect := New().
+		InsertInto("users", "email", "name").
+		FromSelect(New().Select("email", "name").From("staging")).
+		OnConflictDoUpdate(nil, Set("name", Excluded("name")))
+
+	assertBuild(t, fromSelect,
+		"INSERT INTO users (email, name) SELECT email, name FROM staging ON DUPLICATE KEY UPDATE name = VALUES(name)",
+		nil,
+	)
+
+	assertBuildError(t, New().Select(Excluded("name")).WithDialect(DialectClickHouse), ErrUnsupportedByDialect)
+}
+
 func TestInsertIgnoreMySQL(t *testing.T) {
 	q := New().
 		InsertInto("users", "email").
@@ -818,14 +1241,122 @@ func TestInsertIgnoreSQLite(t *testing.T) {
 	)
 }
 
-func TestInsertIgnoreWithExplicitConflictPanics(t *testing.T) {
-	assertPanicsWith(t, func() {
+func TestInsertIgnoreWithExplicitConflictErrors(t *testing.T) {
+	assertBuildError(t,
 		New().
 			InsertInto("users", "email").
 			InsertIgnore().
-			OnConflictDoUpdate([]string{"email"}, Set("name", Raw("EXCLUDED.name"))).
-			Build()
-	}, "INSERT IGNORE não pode ser combinado com handlers explícitos de conflito")
+			OnConflictDoUpdate([]string{"email"}, Set("name", Raw("EXCLUDED.name"))),
+		ErrConflictingClauses,
+	)
+}
+
+func TestInsertFromSelect(t *testing.T) {
+	recent := New().
+		Select("id", "email").
+		From("users").
+		Where(Col("created_at").Gt(Raw("?", "2024-01-01")))
+
+	pg := New().
+		WithDialect(DialectPostgres).
+		With("banned", New().Select("user_id").From("bans").Where(Col("active").Eq(true))).
+		InsertInto("archive", "id", "email").
+		FromSelect(New().
+			Select("id", "email").
+			From("users").
+			Where(Col("id").In(New().Select("user_id").From("banned")), Col("deleted").Eq(false))).
+		OnConflictDoUpdate([]string{"id"}, Set("email", Raw("EXCLUDED.email"))).
+		Returning("id")
+
+	assertBuild(t, pg,
+		"WITH banned AS (SELECT user_id FROM bans WHERE (active = $1)) INSERT INTO archive (id, email) SELECT id, email FROM users WHERE (id IN (SELECT user_id FROM banned) AND deleted = $2) ON CONFLICT (id) DO UPDATE SET email = EXCLUDED.email RETURNING id",
+		[]any{true, false},
+	)
+
+	mysql := New().
+		With("recent", recent).
+		InsertInto("archive", "id", "email").
+		FromSelect(New().Select("id", "email").From("recent")).
+		InsertIgnore()
+
+	assertBuild(t, mysql,
+		"INSERT IGNORE INTO archive (id, email) WITH recent AS (SELECT id, email FROM users WHERE (created_at > ?)) SELECT id, email FROM recent",
+		[]any{"2024-01-01"},
+	)
+
+	nested := New().
+		WithRecursive("tree", New().Select("id").From("nodes")).
+		InsertInto("archive", "id").
+		FromSelect(New().With("recent", recent).Select("recent.id").From("recent").Join("tree", Col("tree.id").Eq(Col("recent.id"))))
+
+	assertBuild(t, nested,
+		"INSERT INTO archive (id) WITH RECURSIVE tree AS (SELECT id FROM nodes), recent AS (SELECT id, email FROM users WHERE (created_at > ?)) "+
+			"SELECT recent.id FROM recent JOIN tree ON (tree.id = recent.id)",
+		[]any{"2024-01-01"},
+	)
+
+	assertBuildError(t,
+		New().With("recent", recent).InsertInto("archive", "id").FromSelect(New().With("recent", recent).Select("id").From("recent")),
+		ErrConflictingClauses,
+	)
+
+	sqlite := New().
+		WithDialect(DialectSQLite).
+		InsertInto("archive", "id", "email").
+		FromSelect(New().Select("id", "email").From("users")).
+		OnConflictDoNothing("id")
+
+	assertBuild(t, sqlite,
+		"INSERT INTO archive (id, email) SELECT id, email FROM users WHERE true ON CONFLICT (id) DO NOTHING",
+		nil,
+	)
+
+	oracle := New().
+		WithDialect(DialectOracle).
+		InsertInto("archive", "id", "email").
+		FromSelect(recent).
+		OnConflictDoNothing("id")
+
+	assertBuild(t, oracle,
+		`MERGE INTO archive USING (SELECT id, email FROM users WHERE (created_at > :1)) excluded ON (archive.id = excluded.id) WHEN NOT MATCHED THEN INSERT (id, email) VALUES (excluded.id, excluded.email)`,
+		[]any{"2024-01-01"},
+	)
+}
+
+func TestDefaultValuesAndKeyword(t *testing.T) {
+	assertBuild(t, New().WithDialect(DialectPostgres).InsertInto("events").DefaultValues().Returning("id"),
+		"INSERT INTO events DEFAULT VALUES RETURNING id", nil)
+	assertBuild(t, New().WithDialect(DialectSQLite).InsertInto("events").DefaultValues(),
+		"INSERT INTO events DEFAULT VALUES", nil)
+	assertBuild(t, New().WithDialect(DialectSQLServer).InsertInto("events").DefaultValues().Returning("id"),
+		"INSERT INTO events OUTPUT INSERTED.id DEFAULT VALUES", nil)
+	assertBuild(t, New().InsertInto("events").DefaultValues(),
+		"INSERT INTO events () VALUES ()", nil)
+
+	assertBuild(t,
+		New().WithDialect(DialectPostgres).InsertInto("users", "email", "status").Values("a@example.com", Default()),
+		"INSERT INTO users (email, status) VALUES ($1, DEFAULT)",
+		[]any{"a@example.com"},
+	)
+
+	assertBuild(t,
+		New().Update("users").Set(Set("status", Default())).Where(Col("id").Eq(1)),
+		"UPDATE users SET status = DEFAULT WHERE (id = ?)",
+		[]any{1},
+	)
+
+	assertBuildError(t, New().WithDialect(DialectOracle).InsertInto("events").DefaultValues(), ErrUnsupportedByDialect)
+	assertBuildError(t, New().WithDialect(DialectSQLite).InsertInto("users", "status").Values(Default()), ErrUnsupportedByDialect)
+	assertBuildError(t, New().InsertInto("events", "id").Values(1).DefaultValues(), ErrConflictingClauses)
+	assertBuildError(t, New().Select("id").From("users").Where(Col("x").Eq(Default())), ErrInvalidClause)
+	assertBuildError(t, New().Select(Default()).From("users"), ErrInvalidClause)
+	assertBuildError(t, New().Update("users").Set(Set("status", Func("COALESCE", Default(), 1))), ErrInvalidClause)
+}
+
+func TestInsertFromSelectErrors(t *testing.T) {
+	assertBuildError(t, New().InsertInto("archive", "id").FromSelect(nil), ErrNilQuery)
+	assertBuildError(t, New().InsertInto("archive", "id").Values(1).FromSelect(New().Select("id").From("users")), ErrConflictingClauses)
+	assertBuildError(t, New().InsertInto("archive", "id").FromSelect(New().DeleteFrom("users")), ErrInvalidClause)
 }
 
 func TestInsertIgnoreWithoutTableReturnsError(t *testing.T) {
@@ -834,14 +1365,8 @@ func TestInsertIgnoreWithoutTableReturnsError(t *testing.T) {
 		Values("a@example.com")
 
 	_, _, err := q.BuildContext(context.Background())
-	if err == nil {
-		t.Fatalf("expected validation error, got nil")
-	}
-
-	const want = "InsertInto must be called before InsertIgnore/Build for INSERT queries"
-
-	if err.Error() != want {
-		t.Fatalf("unexpected error.\nwant: %s\n got: %v", want, err)
+	if !errors.Is(err, ErrMissingInsertTable) {
+		t.Fatalf("expected ErrMissingInsertTable, got %v", err)
 	}
 }
 
@@ -904,26 +1429,111 @@ func TestDefaultDialectSwitching(t *testing.T) {
 	)
 }
 
-func TestReturningPanicsOnSelect(t *testing.T) {
-	assertPanicsWith(t, func() {
-		New().Select("id").Returning("id")
-	}, "RETURNING is not supported on SELECT queries")
+func TestReturningErrorsOnSelect(t *testing.T) {
+	assertBuildError(t, New().Select("id").Returning("id"), ErrInvalidClause)
 }
 
-func TestUpdateWithoutSetPanics(t *testing.T) {
-	assertPanicsWith(t, func() {
-		New().Update("users").Build()
-	}, "UPDATE requires at least one SET clause")
+func TestUpdateWithoutSetErrors(t *testing.T) {
+	assertBuildError(t, New().Update("users"), ErrMissingSetClause)
 }
 
-func TestEmptyInListPanics(t *testing.T) {
-	assertPanicsWith(t, func() {
-		Col("id").In()
-	}, "IN list cannot be empty")
+func TestEmptyInListErrors(t *testing.T) {
+	assertBuildError(t, New().Select("id").From("users").Where(Col("id").In()), ErrEmptyInList)
 
-	assertPanicsWith(t, func() {
-		inPredicate{left: Col("id"), list: nil}.build(&buildContext{dialect: DialectMySQL})
-	}, "IN list cannot be empty")
+	ctx := &buildContext{dialect: DialectMySQL}
+	inPredicate{left: Col("id"), list: nil}.build(ctx)
+
+	if err := ctx.err(); !errors.Is(err, ErrEmptyInList) {
+		t.Fatalf("unexpected accumulated error: %v", err)
+	}
+}
+
+func TestBuildAccumulatesErrors(t *testing.T) {
+	q := New().
+		Update("users").
+		Where(Col("id").In(), Col("status").NotIn())
+
+	_, _, err := q.BuildContext(context.Background())
+	if err == nil {
+		t.Fatalf("expected build error")
+	}
+
+	if !errors.Is(err, ErrMissingSetClause) || !errors.Is(err, ErrEmptyInList) {
+		t.Fatalf("expected joined SET and IN errors, got %v", err)
+	}
+
+	sql, args := q.Build()
+	if sql != "" || args != nil {
+		t.Fatalf("expected Build to discard invalid output, got %q %#v", sql, args)
+	}
+}
+
+func TestMustBuildPanicsWithBuildError(t *testing.T) {
+	defer func() {
+		r := recover()
+		if r == nil {
+			t.Fatalf("expected MustBuild to panic")
+		}
+
+		err, ok := r.(error)
+		if !ok {
+			t.Fatalf("panic is not an error: %#v", r)
+		}
+
+		if !errors.Is(err, ErrMissingSetClause) {
+			t.Fatalf("unexpected panic error: %v", err)
+		}
+	}()
+
+	New().Update("users").MustBuild()
+}
+
+func TestBuildErrorDetails(t *testing.T) {
+	_, _, err := New().
+		WithDialect(DialectPostgres).
+		Select("id").
+		From("posts").
+		Where(Match("title").Against("oops")).
+		BuildContext(context.Background())
+
+	var buildErr *BuildError
+	if !errors.As(err, &buildErr) {
+		t.Fatalf("expected *BuildError, got %T", err)
+	}
+
+	if buildErr.Clause != "MATCH ... AGAINST" || buildErr.QueryType != "SELECT" || buildErr.Dialect != dialectPostgres {
+		t.Fatalf("unexpected error details: %#v", buildErr)
+	}
+
+	const want = "chizuql: feature not supported by dialect: MATCH ... AGAINST (query SELECT, dialect postgres)"
+
+	if err.Error() != want {
+		t.Fatalf("unexpected message.\nwant: %s\n got: %s", want, err.Error())
+	}
+}
+
+func TestSubqueryErrorsReportTheirQueryType(t *testing.T) {
+	_, _, err := New().
+		DeleteFrom("sessions").
+		Where(Col("id").In(New().Select("id").From("expired").Where(Col("kind").In()))).
+		BuildContext(context.Background())
+
+	var buildErr *BuildError
+	if !errors.As(err, &buildErr) {
+		t.Fatalf("expected *BuildError, got %T", err)
+	}
+
+	if buildErr.QueryType != "SELECT" || buildErr.Clause != "IN" {
+		t.Fatalf("unexpected error details: %#v", buildErr)
+	}
+}
+
+func TestMustBuildReturnsSQL(t *testing.T) {
+	sql, args := New().Select("id").From("users").Where(Col("id").Eq(1)).MustBuild()
+
+	if sql != "SELECT id FROM users WHERE (id = ?)" || !reflect.DeepEqual(args, []any{1}) {
+		t.Fatalf("unexpected MustBuild output: %s %#v", sql, args)
+	}
 }
 
 func TestNotInWithValues(t *testing.T) {
@@ -955,26 +1565,90 @@ func TestNotInWithSubquery(t *testing.T) {
 	)
 }
 
-func assertPanicsWith(t *testing.T, fn func(), msg string) {
-	t.Helper()
+func TestExistsPredicates(t *testing.T) {
+	orders := New().
+		Select(Raw("1")).
+		From(TableAlias("orders", "o")).
+		Where(Col("o.user_id").Eq(Col("u.id")), Col("o.total").Gt(100))
 
-	defer func() {
-		r := recover()
-		if r == nil {
-			t.Fatalf("expected panic with message %q", msg)
-		}
+	q := New().
+		WithDialect(DialectPostgres).
+		Select("u.id").
+		From(TableAlias("users", "u")).
+		Where(
+			Col("u.active").Eq(true),
+			Exists(orders),
+			NotExists(New().Select(Raw("1")).From(TableAlias("bans", "b")).Where(Col("b.user_id").Eq(Col("u.id")))),
+		).
+		Limit(10)
 
-		got, ok := r.(string)
-		if !ok {
-			t.Fatalf("panic is not a string: %#v", r)
+	assertBuild(t, q,
+		"SELECT u.id FROM users AS u WHERE (u.active = $1 AND EXISTS (SELECT 1 FROM orders AS o WHERE (o.user_id = u.id AND o.total > $2)) "+
+			"AND NOT EXISTS (SELECT 1 FROM bans AS b WHERE (b.user_id = u.id))) LIMIT 10",
+		[]any{true, 100},
+	)
 
-			return
-		}
+	assertBuildError(t, New().Select("id").From("users").Where(Exists(nil)), ErrNilQuery)
+	assertBuildError(t, New().Select("id").From("users").Where(Col("x").EqAny((*Query)(nil))), ErrNilQuery)
+	assertBuildError(t, New().WithDialect(DialectSQLite).Select("id").From("users").Where(Col("x").NeAll((*Query)(nil))), ErrNilQuery)
+	assertBuildError(t, New().WithDialect(DialectPostgres).Select("id").From("users").Where(Col("x").GtAll((*Query)(nil))), ErrNilQuery)
+}
 
-		if got != msg {
-			t.Fatalf("unexpected panic message. want %q got %q", msg, got)
-		}
-	}()
+func TestQuantifiedPredicates(t *testing.T) {
+	prices := New().Select("price").From("products").Where(Col("category").Eq("books"))
+
+	assertBuild(t,
+		New().WithDialect(DialectPostgres).Select("id").From("products").Where(Col("stock").Gt(0), Col("price").GtAll(prices)),
+		"SELECT id FROM products WHERE (stock > $1 AND price > ALL (SELECT price FROM products WHERE (category = $2)))",
+		[]any{0, "books"},
+	)
+
+	assertBuild(t,
+		New().Select("id").From("products").Where(Col("price").LteAny(prices)),
+		"SELECT id FROM products WHERE (price <= ANY (SELECT price FROM products WHERE (category = ?)))",
+		[]any{"books"},
+	)
 
-	fn()
+	ids := []int{1, 2, 3}
+
+	assertBuild(t,
+		New().WithDialect(DialectPostgres).Select("id").From("users").Where(Col("id").EqAny(ids), Col("role").NeAll([]string{"bot"})),
+		"SELECT id FROM users WHERE (id = ANY($1) AND role <> ALL($2))",
+		[]any{ids, []string{"bot"}},
+	)
+
+	assertBuild(t,
+		New().WithDialect(DialectPostgres).Select("id").From("posts").Where(Col("author").EqAny(Col("editors"))),
+		"SELECT id FROM posts WHERE (author = ANY(editors))",
+		nil,
+	)
+
+	assertBuild(t,
+		New().WithDialect(DialectSQLite).Select("id").From("users").Where(Col("id").EqAny(ids), Col("id").NeAll(prices)),
+		"SELECT id FROM users WHERE (id IN (?, ?, ?) AND id NOT IN (SELECT price FROM products WHERE (category = ?)))",
+		[]any{1, 2, 3, "books"},
+	)
+
+	assertBuildError(t, New().Select("id").From("users").Where(Col("id").GtAny(ids)), ErrUnsupportedByDialect)
+	assertBuildError(t, New().WithDialect(DialectSQLite).Select("id").From("products").Where(Col("price").GtAll(prices)), ErrUnsupportedByDialect)
+	assertBuildError(t, New().Select("id").From("users").Where(Col("id").EqAny([]int{})), ErrEmptyInList)
+	assertBuildError(t, New().WithDialect(DialectPostgres).Select("id").From("users").Where(Col("x").GtAny(5)), ErrInvalidClause)
+	assertBuildError(t, New().Select("id").From("users").Where(Col("id").EqAny("1")), ErrInvalidClause)
+}
+
+func assertBuildError(t *testing.T, q *Query, want error) {
+	t.Helper()
+
+	sql, args, err := q.BuildContext(context.Background())
+	if err == nil {
+		t.Fatalf("expected build error %q, got SQL %q", want, sql)
+	}
+
+	if !errors.Is(err, want) {
+		t.Fatalf("unexpected build error. want %q got %q", want, err)
+	}
+
+	if sql != "" || args != nil {
+		t.Fatalf("expected empty output on error, got %q %#v", sql, args)
+	}
 }
@@ -0,0 +1,589 @@
+package chizuql
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// DialectKind identifies a SQL dialect in hints, build reports and build errors.
+type DialectKind string
+
+const (
+	dialectMySQL      DialectKind = "mysql"
+	dialectPostgres   DialectKind = "postgres"
+	dialectSQLite     DialectKind = "sqlite"
+	dialectSQLServer  DialectKind = "sqlserver"
+	dialectOracle     DialectKind = "oracle"
+	dialectClickHouse DialectKind = "clickhouse"
+)
+
+// Dialect describes how a database renders placeholders, identifiers and dialect-specific clauses.
+//
+// The builder never switches on concrete dialects: every dialect-specific decision consults Features, so packages
+// outside chizuql can ship their own dialects. The simplest way is to embed a built-in dialect and override the
+// methods that differ:
+//
+//	type cockroach struct{ chizuql.Dialect }
+//
+//	func (cockroach) Kind() chizuql.DialectKind { return "cockroachdb" }
+//
+//	var DialectCockroach chizuql.Dialect = cockroach{chizuql.DialectPostgres}
+type Dialect interface {
+	// Kind returns the identifier used to filter optimizer hints and reported in BuildReport and BuildError.
+	Kind() DialectKind
+	// Placeholder renders the bind marker for the 1-based argument position.
+	Placeholder(position int) string
+	// QuoteIdentifier quotes a single identifier part, without schema or table qualifiers.
+	QuoteIdentifier(name string) string
+	// Features describes which clauses the dialect supports and how they are spelled.
+	Features() DialectFeatures
+}
+
+// DialectFeatures lists the capabilities consulted by the builder while rendering.
+//
+// Zero values describe the most conservative dialect: positional placeholders, LIMIT/OFFSET pagination, standard
+// ROLLUP/CUBE grouping, table aliases without AS, no placeholder limit and no support for RETURNING, upserts, INSERT
+// IGNORE, MERGE, INTERSECT/EXCEPT, quantified comparisons, lateral, natural or USING joins, multi-table or limited
+// UPDATE and DELETE, DEFAULT VALUES, the DEFAULT keyword, row locks, full-text search, JSON helpers, WITH ORDINALITY or
+// ClickHouse-specific clauses.
+type DialectFeatures struct {
+	// Placeholders tells whether Placeholder renders positional (?) or numbered ($1) markers.
+	Placeholders PlaceholderStyle
+	// BackslashEscapes reports that a backslash escapes the next character inside string literals, as in MySQL's
+	// default SQL mode, so raw fragments do not mistake \' for the end of a literal.
+	BackslashEscapes bool
+	// Limit selects how LIMIT and OFFSET are rendered.
+	Limit LimitSyntax
+	// Returning selects how RETURNING is rendered for INSERT, UPDATE and DELETE.
+	Returning ReturningSyntax
+	// ReturningOmittable reports that older servers of the dialect lack RETURNING, so MySQLReturningOmit drops the
+	// clause instead of rendering it.
+	ReturningOmittable bool
+	// Upsert selects how OnConflictDoNothing/OnConflictDoUpdate are rendered.
+	Upsert UpsertSyntax
+	// ConflictConstraint reports support for ON CONFLICT ON CONSTRAINT targets (see OnConflictConstraint).
+	ConflictConstraint bool
+	// Merge selects how MERGE statements are rendered. Dialects without MERGE fall back to their Upsert syntax.
+	Merge MergeSyntax
+	// InsertIgnore selects how InsertIgnore is rendered.
+	InsertIgnore InsertIgnoreSyntax
+	// UpdateJoin selects how UPDATE queries with From or joins are rendered.
+	UpdateJoin UpdateJoinSyntax
+	// DeleteJoin selects how DELETE queries with From or joins are rendered.
+	DeleteJoin DeleteJoinSyntax
+	// LimitedDML selects how OrderBy, Limit and Offset are rendered on UPDATE and DELETE.
+	LimitedDML LimitedDMLSyntax
+	// RowIdentifier names the pseudo-column that identifies a table row (ctid, ROWID), used by LimitedDMLSubquery.
+	RowIdentifier string
+	// Lateral selects how JoinLateral, LeftJoinLateral, CrossApply and OuterApply are rendered.
+	Lateral LateralSyntax
+	// NaturalJoin reports support for NATURAL JOIN.
+	NaturalJoin bool
+	// JoinUsing reports support for JOIN ... USING (columns).
+	JoinUsing bool
+	// Quantifiers selects how quantified comparisons (EqAny, GtAll...) are rendered.
+	Quantifiers QuantifierSyntax
+	// SetOperations selects which set operators besides UNION are available and how operands are grouped.
+	SetOperations SetOperationSyntax
+	// SetOperationsOptIn reports that older servers of the dialect lack INTERSECT and EXCEPT, so only UNION is
+	// available until MySQLSetOperationsAll opts in to SetOperations.
+	SetOperationsOptIn bool
+	// Lock selects how ForUpdate and LockInShareMode are rendered.
+	Lock LockSyntax
+	// LockModifiers reports support for the SkipLocked and NoWait lock modifiers.
+	LockModifiers bool
+	// TextSearch selects which full-text search builder (Match or TsVector) is available.
+	TextSearch TextSearchSyntax
+	// JSON renders JSONExtract, JSONExtractText and JSONContains. A nil renderer disables the JSON helpers.
+	JSON JSONRenderer
+	// WithOrdinality reports support for WITH ORDINALITY on set-returning functions.
+	WithOrdinality bool
+	// TableAliasAs reports whether table aliases are introduced with AS (FROM users AS u). Oracle rejects the keyword.
+	TableAliasAs bool
+	// Grouping selects how Rollup and Cube are rendered in GROUP BY.
+	Grouping GroupingSyntax
+	// LimitBy reports support for LIMIT n BY expressions.
+	LimitBy bool
+	// TableModifiers reports support for the FINAL and SAMPLE table modifiers.
+	TableModifiers bool
+	// Settings reports support for a trailing SETTINGS clause.
+	Settings bool
+	// DefaultValues selects how DefaultValues is rendered.
+	DefaultValues DefaultValuesSyntax
+	// DefaultKeyword reports support for the DEFAULT keyword in VALUES rows and SET assignments (see Default).
+	DefaultKeyword bool
+	// CTEInInsertSelect renders the CTEs of INSERT ... SELECT queries right before the SELECT instead of ahead of the
+	// statement, as MySQL and Oracle require.
+	CTEInInsertSelect bool
+	// UpsertSelectWhere adds WHERE true to INSERT ... SELECT sources without a WHERE clause when a conflict handler
+	// follows, avoiding the ON CONFLICT parsing ambiguity of SQLite.
+	UpsertSelectWhere bool
+	// MaxPlaceholders is the largest number of bind arguments a statement accepts, used by BuildBatches when no
+	// explicit budget is given. Zero means unlimited.
+	MaxPlaceholders int
+}
+
+// PlaceholderStyle describes how bind markers are numbered.
+type PlaceholderStyle int
+
+const (
+	// PlaceholderPositional renders the same marker for every argument (ex: ?).
+	PlaceholderPositional PlaceholderStyle = iota
+	// PlaceholderNumbered renders markers carrying the argument position (ex: $1, $2).
+	PlaceholderNumbered
+)
+
+// LimitSyntax describes how result pagination is rendered.
+type LimitSyntax int
+
+const (
+	// LimitOffset renders LIMIT n OFFSET m.
+	LimitOffset LimitSyntax = iota
+	// LimitOffsetFetch renders OFFSET m ROWS FETCH NEXT n ROWS ONLY, falling back to SELECT TOP (n) when the query has
+	// a limit but no ORDER BY nor offset. Offsets without ORDER BY are ordered by (SELECT NULL).
+	LimitOffsetFetch
+	// LimitFetchFirst renders OFFSET m ROWS FETCH FIRST n ROWS ONLY.
+	LimitFetchFirst
+)
+
+// ReturningSyntax describes how DML statements return affected rows.
+type ReturningSyntax int
+
+const (
+	// ReturningUnsupported rejects Returning with ErrUnsupportedByDialect.
+	ReturningUnsupported ReturningSyntax = iota
+	// ReturningClause renders a trailing RETURNING clause.
+	ReturningClause
+	// ReturningOutput renders an OUTPUT clause reading from the INSERTED (INSERT/UPDATE) or DELETED (DELETE)
+	// pseudo-tables.
+	ReturningOutput
+	// ReturningClauseInto renders RETURNING ... INTO, binding the destinations configured with ReturningInto.
+	ReturningClauseInto
+)
+
+// UpsertSyntax describes how conflict handlers are rendered.
+type UpsertSyntax int
+
+const (
+	// UpsertUnsupported rejects conflict handlers with ErrUnsupportedByDialect.
+	UpsertUnsupported UpsertSyntax = iota
+	// UpsertOnConflict renders ON CONFLICT (...) DO NOTHING/DO UPDATE SET.
+	UpsertOnConflict
+	// UpsertOnDuplicateKey renders ON DUPLICATE KEY UPDATE, ignoring conflict targets and DO NOTHING handlers.
+	UpsertOnDuplicateKey
+	// UpsertMerge rewrites the INSERT as MERGE INTO ... USING (SELECT ... FROM dual) excluded, matching rows on the
+	// conflict target columns. Assignments can keep referencing EXCLUDED.column.
+	UpsertMerge
+)
+
+// MergeSyntax describes how MERGE statements are rendered.
+type MergeSyntax int
+
+const (
+	// MergeUnsupported rewrites MERGE as an upsert when possible and fails with ErrUnsupportedByDialect otherwise.
+	MergeUnsupported MergeSyntax = iota
+	// MergeStandard renders WHEN [NOT] MATCHED [AND condition] THEN arms, including DO NOTHING.
+	MergeStandard
+	// MergeSemicolonTerminated renders standard arms, omits DO NOTHING arms and ends the statement with a semicolon.
+	MergeSemicolonTerminated
+	// MergeWhereClauses renders conditions as a WHERE clause on the arm action, allowing one arm of each kind and
+	// omitting DO NOTHING arms.
+	MergeWhereClauses
+)
+
+// InsertIgnoreSyntax describes how InsertIgnore is rendered.
+type InsertIgnoreSyntax int
+
+const (
+	// InsertIgnoreUnsupported rejects InsertIgnore with ErrUnsupportedByDialect.
+	InsertIgnoreUnsupported InsertIgnoreSyntax = iota
+	// InsertIgnoreKeyword renders INSERT IGNORE.
+	InsertIgnoreKeyword
+	// InsertIgnoreOnConflict renders ON CONFLICT DO NOTHING.
+	InsertIgnoreOnConflict
+)
+
+// UpdateJoinSyntax describes how UPDATE queries with From or joins are rendered.
+type UpdateJoinSyntax int
+
+const (
+	// UpdateJoinUnsupported rejects From and joins on UPDATE with ErrUnsupportedByDialect.
+	UpdateJoinUnsupported UpdateJoinSyntax = iota
+	// UpdateJoinFrom renders UPDATE t SET ... FROM ..., promoting the first join to the FROM list when From is unset.
+	UpdateJoinFrom
+	// UpdateJoinInline renders UPDATE t [, from] JOIN ... SET ...
+	UpdateJoinInline
+	// UpdateJoinTargetFrom renders UPDATE alias SET ... FROM t [, from] JOIN ...
+	UpdateJoinTargetFrom
+)
+
+// DeleteJoinSyntax describes how DELETE queries with From or joins are rendered.
+type DeleteJoinSyntax int
+
+const (
+	// DeleteJoinUnsupported rejects From and joins on DELETE with ErrUnsupportedByDialect.
+	DeleteJoinUnsupported DeleteJoinSyntax = iota
+	// DeleteJoinUsing renders DELETE FROM t USING ..., promoting the first join to the USING list when From is unset.
+	DeleteJoinUsing
+	// DeleteJoinTargetFrom renders DELETE alias FROM t [, from] JOIN ...
+	DeleteJoinTargetFrom
+)
+
+// LimitedDMLSyntax describes how OrderBy, Limit and Offset are rendered on single-table UPDATE and DELETE queries.
+type LimitedDMLSyntax int
+
+const (
+	// LimitedDMLUnsupported rejects ordered or limited UPDATE and DELETE with ErrUnsupportedByDialect.
+	LimitedDMLUnsupported LimitedDMLSyntax = iota
+	// LimitedDMLClause renders ORDER BY and LIMIT after WHERE, rejecting Offset.
+	LimitedDMLClause
+	// LimitedDMLAfterReturning renders ORDER BY, LIMIT and OFFSET after RETURNING.
+	LimitedDMLAfterReturning
+	// LimitedDMLTop renders TOP (n) after the verb, rejecting OrderBy and Offset.
+	LimitedDMLTop
+	// LimitedDMLSubquery rewrites WHERE as RowIdentifier IN (SELECT RowIdentifier FROM ... LIMIT n).
+	LimitedDMLSubquery
+)
+
+// DefaultValuesSyntax describes how an INSERT made only of column defaults is rendered.
+type DefaultValuesSyntax int
+
+const (
+	// DefaultValuesUnsupported rejects DefaultValues with ErrUnsupportedByDialect.
+	DefaultValuesUnsupported DefaultValuesSyntax = iota
+	// DefaultValuesClause renders INSERT INTO table DEFAULT VALUES.
+	DefaultValuesClause
+	// DefaultValuesEmptyRow renders INSERT INTO table () VALUES ().
+	DefaultValuesEmptyRow
+)
+
+// LateralSyntax describes how joins against correlated subqueries are rendered.
+type LateralSyntax int
+
+const (
+	// LateralUnsupported rejects lateral joins with ErrUnsupportedByDialect.
+	LateralUnsupported LateralSyntax = iota
+	// LateralJoin renders [LEFT|CROSS] JOIN LATERAL, adding ON true to unconditioned inner and left joins.
+	LateralJoin
+	// LateralApply renders CROSS APPLY and OUTER APPLY, rejecting ON predicates.
+	LateralApply
+)
+
+// QuantifierSyntax describes which operands quantified comparisons (op ANY/ALL) accept.
+type QuantifierSyntax int
+
+const (
+	// QuantifiersUnsupported rejects quantified comparisons with ErrUnsupportedByDialect, except EqAny and NeAll,
+	// which fall back to IN and NOT IN.
+	QuantifiersUnsupported QuantifierSyntax = iota
+	// QuantifiersSubquery renders op ANY (SELECT ...) and op ALL (SELECT ...).
+	QuantifiersSubquery
+	// QuantifiersArray also renders op ANY($1) with a single array argument for slices and array expressions.
+	QuantifiersArray
+)
+
+// SetOperationSyntax describes which set operators are available and how their operands are rendered.
+type SetOperationSyntax int
+
+const (
+	// SetOperationsUnion only supports UNION [ALL], rejecting INTERSECT and EXCEPT with ErrUnsupportedByDialect.
+	SetOperationsUnion SetOperationSyntax = iota
+	// SetOperationsDistinct supports INTERSECT and EXCEPT without their ALL variants.
+	SetOperationsDistinct
+	// SetOperationsAll supports INTERSECT [ALL] and EXCEPT [ALL].
+	SetOperationsAll
+	// SetOperationsMinus supports INTERSECT and renders EXCEPT as MINUS, without ALL variants.
+	SetOperationsMinus
+	// SetOperationsCompound supports INTERSECT and EXCEPT without ALL variants and renders operands without
+	// parentheses, relying on left-to-right evaluation. Ordered, limited or compound operands are rejected or wrapped
+	// in SELECT * FROM (...).
+	SetOperationsCompound
+)
+
+// LockSyntax describes how row-level locks are rendered.
+type LockSyntax int
+
+const (
+	// LockUnsupported rejects row-level locks with ErrUnsupportedByDialect.
+	LockUnsupported LockSyntax = iota
+	// LockStandard renders FOR UPDATE and FOR SHARE.
+	LockStandard
+	// LockShareMode renders FOR UPDATE and LOCK IN SHARE MODE.
+	LockShareMode
+	// LockTableHints renders WITH (UPDLOCK, ROWLOCK) and WITH (HOLDLOCK, ROWLOCK) after the FROM table.
+	LockTableHints
+	// LockForUpdateOnly renders FOR UPDATE and rejects shared locks.
+	LockForUpdateOnly
+)
+
+// TextSearchSyntax describes which full-text search builder a dialect renders.
+type TextSearchSyntax int
+
+const (
+	// TextSearchUnsupported rejects full-text search builders with ErrUnsupportedByDialect.
+	TextSearchUnsupported TextSearchSyntax = iota
+	// TextSearchMatchAgainst enables Match(...).Against/Score (MATCH ... AGAINST).
+	TextSearchMatchAgainst
+	// TextSearchTsVector enables TsVector builders (to_tsvector/ts_rank).
+	TextSearchTsVector
+)
+
+// GroupingSyntax describes how ROLLUP and CUBE grouping elements are rendered.
+type GroupingSyntax int
+
+const (
+	// GroupingStandard renders GROUP BY ROLLUP (a, b) and GROUP BY CUBE (a, b).
+	GroupingStandard GroupingSyntax = iota
+	// GroupingWithModifier renders GROUP BY a, b WITH ROLLUP/WITH CUBE when the element is the only GROUP BY entry.
+	GroupingWithModifier
+)
+
+// JSONRenderer renders JSON helpers for a dialect. Column and argument fragments are already rendered.
+type JSONRenderer interface {
+	// JSONExtract renders the extraction of path from column, unwrapping the result into text when unwrap is set.
+	JSONExtract(column, path string, unwrap bool) string
+	// JSONContains renders a predicate testing whether column contains value.
+	JSONContains(column, value string) string
+}
+
+type mysqlJSON struct{}
+
+func (mysqlJSON) JSONExtract(column, path string, unwrap bool) string {
+	expr := fmt.Sprintf("JSON_EXTRACT(%s, %s)", column, path)
+	if unwrap {
+		return fmt.Sprintf("JSON_UNQUOTE(%s)", expr)
+	}
+
+	return expr
+}
+
+func (mysqlJSON) JSONContains(column, value string) string {
+	return fmt.Sprintf("JSON_CONTAINS(%s, %s)", column, value)
+}
+
+type postgresJSON struct{}
+
+func (postgresJSON) JSONExtract(column, path string, unwrap bool) string {
+	expr := fmt.Sprintf("jsonb_path_query_first(to_jsonb(%s), (%s)::jsonpath)", column, path)
+	if unwrap {
+		return fmt.Sprintf("(%s)::text", expr)
+	}
+
+	return expr
+}
+
+func (postgresJSON) JSONContains(column, value string) string {
+	return fmt.Sprintf("to_jsonb(%s) @> (%s)::jsonb", column, value)
+}
+
+// sqlDialect implements the built-in dialects from static configuration.
+type sqlDialect struct {
+	kind        DialectKind
+	placeholder string
+	quoteOpen   string
+	quoteClose  string
+	features    DialectFeatures
+}
+
+func (d sqlDialect) Kind() DialectKind { return d.kind }
+
+func (d sqlDialect) Placeholder(position int) string {
+	if d.features.Placeholders == PlaceholderNumbered {
+		return d.placeholder + strconv.Itoa(position)
+	}
+
+	return d.placeholder
+}
+
+func (d sqlDialect) QuoteIdentifier(name string) string {
+	return d.quoteOpen + strings.ReplaceAll(name, d.quoteClose, d.quoteClose+d.quoteClose) + d.quoteClose
+}
+
+func (d sqlDialect) Features() DialectFeatures { return d.features }
+
+var (
+	// DialectMySQL renders placeholders as ?
+	DialectMySQL Dialect = sqlDialect{
+		kind:        dialectMySQL,
+		placeholder: "?",
+		quoteOpen:   "`",
+		quoteClose:  "`",
+		features: DialectFeatures{
+			BackslashEscapes:   true,
+			Returning:          ReturningClause,
+			ReturningOmittable: true,
+			Upsert:             UpsertOnDuplicateKey,
+			InsertIgnore:       InsertIgnoreKeyword,
+			UpdateJoin:         UpdateJoinInline,
+			DeleteJoin:         DeleteJoinTargetFrom,
+			LimitedDML:         LimitedDMLClause,
+			Quantifiers:        QuantifiersSubquery,
+			Lateral:            LateralJoin,
+			NaturalJoin:        true,
+			JoinUsing:          true,
+			SetOperations:      SetOperationsAll,
+			SetOperationsOptIn: true,
+			Lock:               LockShareMode,
+			LockModifiers:      true,
+			TextSearch:         TextSearchMatchAgainst,
+			JSON:               mysqlJSON{},
+			TableAliasAs:       true,
+			CTEInInsertSelect:  true,
+			DefaultValues:      DefaultValuesEmptyRow,
+			DefaultKeyword:     true,
+			MaxPlaceholders:    65535,
+		},
+	}
+	// DialectPostgres renders placeholders as $1, $2, ...
+	DialectPostgres Dialect = sqlDialect{
+		kind:        dialectPostgres,
+		placeholder: "$",
+		quoteOpen:   `"`,
+		quoteClose:  `"`,
+		features: DialectFeatures{
+			Placeholders:       PlaceholderNumbered,
+			Returning:          ReturningClause,
+			Upsert:             UpsertOnConflict,
+			ConflictConstraint: true,
+			Merge:              MergeStandard,
+			InsertIgnore:       InsertIgnoreOnConflict,
+			UpdateJoin:         UpdateJoinFrom,
+			DeleteJoin:         DeleteJoinUsing,
+			LimitedDML:         LimitedDMLSubquery,
+			RowIdentifier:      "ctid",
+			Quantifiers:        QuantifiersArray,
+			Lateral:            LateralJoin,
+			NaturalJoin:        true,
+			JoinUsing:          true,
+			SetOperations:      SetOperationsAll,
+			Lock:               LockStandard,
+			LockModifiers:      true,
+			TextSearch:         TextSearchTsVector,
+			JSON:               postgresJSON{},
+			WithOrdinality:     true,
+			TableAliasAs:       true,
+			DefaultValues:      DefaultValuesClause,
+			DefaultKeyword:     true,
+			MaxPlaceholders:    65535,
+		},
+	}
+	// DialectSQLite renders placeholders as ? (SQLite-style)
+	DialectSQLite Dialect = sqlDialect{
+		kind:        dialectSQLite,
+		placeholder: "?",
+		quoteOpen:   `"`,
+		quoteClose:  `"`,
+		features: DialectFeatures{
+			Returning:         ReturningClause,
+			Upsert:            UpsertOnConflict,
+			InsertIgnore:      InsertIgnoreOnConflict,
+			UpdateJoin:        UpdateJoinFrom,
+			LimitedDML:        LimitedDMLAfterReturning,
+			NaturalJoin:       true,
+			JoinUsing:         true,
+			SetOperations:     SetOperationsCompound,
+			Lock:              LockStandard,
+			TableAliasAs:      true,
+			UpsertSelectWhere: true,
+			DefaultValues:     DefaultValuesClause,
+			MaxPlaceholders:   32766,
+		},
+	}
+	// DialectSQLServer renders placeholders as @p1, @p2, ... and bracket-quoted identifiers (T-SQL)
+	DialectSQLServer Dialect = sqlDialect{
+		kind:        dialectSQLServer,
+		placeholder: "@p",
+		quoteOpen:   "[",
+		quoteClose:  "]",
+		features: DialectFeatures{
+			Placeholders:    PlaceholderNumbered,
+			Limit:           LimitOffsetFetch,
+			Returning:       ReturningOutput,
+			Merge:           MergeSemicolonTerminated,
+			UpdateJoin:      UpdateJoinTargetFrom,
+			DeleteJoin:      DeleteJoinTargetFrom,
+			LimitedDML:      LimitedDMLTop,
+			Quantifiers:     QuantifiersSubquery,
+			Lateral:         LateralApply,
+			SetOperations:   SetOperationsDistinct,
+			Lock:            LockTableHints,
+			LockModifiers:   true,
+			TableAliasAs:    true,
+			DefaultValues:   DefaultValuesClause,
+			DefaultKeyword:  true,
+			MaxPlaceholders: 2100,
+		},
+	}
+	// DialectOracle renders bind variables as :1, :2, ... with FETCH FIRST pagination and MERGE upserts
+	DialectOracle Dialect = sqlDialect{
+		kind:        dialectOracle,
+		placeholder: ":",
+		quoteOpen:   `"`,
+		quoteClose:  `"`,
+		features: DialectFeatures{
+			Placeholders:      PlaceholderNumbered,
+			Limit:             LimitFetchFirst,
+			Returning:         ReturningClauseInto,
+			Upsert:            UpsertMerge,
+			Merge:             MergeWhereClauses,
+			LimitedDML:        LimitedDMLSubquery,
+			RowIdentifier:     "ROWID",
+			Quantifiers:       QuantifiersSubquery,
+			Lateral:           LateralApply,
+			NaturalJoin:       true,
+			JoinUsing:         true,
+			SetOperations:     SetOperationsMinus,
+			Lock:              LockForUpdateOnly,
+			LockModifiers:     true,
+			CTEInInsertSelect: true,
+			DefaultKeyword:    true,
+			MaxPlaceholders:   65535,
+		},
+	}
+	// DialectClickHouse renders placeholders as ? and supports LIMIT BY, FINAL/SAMPLE and SETTINGS
+	DialectClickHouse Dialect = sqlDialect{
+		kind:        dialectClickHouse,
+		placeholder: "?",
+		quoteOpen:   "`",
+		quoteClose:  "`",
+		features: DialectFeatures{
+			TableAliasAs:   true,
+			Grouping:       GroupingWithModifier,
+			SetOperations:  SetOperationsDistinct,
+			JoinUsing:      true,
+			LimitBy:        true,
+			TableModifiers: true,
+			Settings:       true,
+		},
+	}
+
+	defaultDialect   = DialectMySQL
+	defaultDialectMu sync.RWMutex
+)
+
+// SetDefaultDialect replaces the package-wide default dialect used by newly created queries.
+func SetDefaultDialect(d Dialect) {
+	defaultDialectMu.Lock()
+	defer defaultDialectMu.Unlock()
+
+	defaultDialect = d
+}
+
+// DefaultDialect returns the package-wide default dialect.
+func DefaultDialect() Dialect {
+	defaultDialectMu.RLock()
+	defer defaultDialectMu.RUnlock()
+
+	return defaultDialect
+}
+
+// requireFeature records ErrUnsupportedByDialect for the feature when the dialect lacks it.
+func requireFeature(ctx *buildContext, supported bool, feature string) bool {
+	if !supported {
+		ctx.addError(newBuildError(feature, ErrUnsupportedByDialect, ""))
+	}
+
+	return supported
+}
@@ -0,0 +1,387 @@
+package chizuql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"testing"
+)
+
+type cockroachDialect struct{ Dialect }
+
+func (cockroachDialect) Kind() DialectKind { return "cockroachdb" }
+
+func (d cockroachDialect) Features() DialectFeatures {
+	features := d.Dialect.Features()
+	features.TextSearch = TextSearchUnsupported
+
+	return features
+}
+
+type mariaDialect struct{ Dialect }
+
+func (mariaDialect) Kind() DialectKind { return "mariadb" }
+
+type colonDialect struct{}
+
+func (colonDialect) Kind() DialectKind                  { return "colon" }
+func (colonDialect) Placeholder(position int) string    { return fmt.Sprintf(":%d", position) }
+func (colonDialect) QuoteIdentifier(name string) string { return `"` + name + `"` }
+func (colonDialect) Features() DialectFeatures {
+	return DialectFeatures{Placeholders: PlaceholderNumbered, JSON: sqliteLikeJSON{}}
+}
+
+type sqliteLikeJSON struct{}
+
+func (sqliteLikeJSON) JSONExtract(column, path string, unwrap bool) string {
+	if unwrap {
+		return fmt.Sprintf("(%s ->> %s)", column, path)
+	}
+
+	return fmt.Sprintf("(%s -> %s)", column, path)
+}
+
+func (sqliteLikeJSON) JSONContains(column, value string) string {
+	return fmt.Sprintf("json_contains(%s, %s)", column, value)
+}
+
+func TestEmbeddedCustomDialect(t *testing.T) {
+	crdb := cockroachDialect{DialectPostgres}
+
+	q := New().
+		WithDialect(crdb).
+		InsertInto("users", "email").
+		Values("a@example.com").
+		OnConflictDoNothing("email").
+		Returning("id").
+		OptimizerHints(PostgresHint("SeqScan(users) OFF"), OptimizerHint("Parallel(2)", crdb))
+
+	assertBuild(t, q,
+		"INSERT /*+ Parallel(2) */ INTO users (email) VALUES ($1) ON CONFLICT (email) DO NOTHING RETURNING id",
+		[]any{"a@example.com"},
+	)
+
+	assertBuildError(t,
+		New().WithDialect(crdb).Select("id").From("posts").Where(TsVector("title").PlainQuery("go")),
+		ErrUnsupportedByDialect,
+	)
+}
+
+func TestCustomDialectCapabilities(t *testing.T) {
+	var report BuildReport
+
+	q := New().
+		WithDialect(colonDialect{}).
+		WithHooks(BuildHookFuncs{After: func(_ context.Context, result BuildResult) error {
+			report = result.Report
+
+			return nil
+		}}).
+		Select(JSONExtractText("payload", "$.name")).
+		From("events").
+		Where(Col("id").Eq(1)).
+		Limit(5)
+
+	assertBuild(t, q,
+		"SELECT (payload ->> :1) FROM events WHERE (id = :2) LIMIT 5",
+		[]any{"$.name", 1},
+	)
+
+	if report.DialectKind != "colon" {
+		t.Fatalf("expected custom dialect kind, got %q", report.DialectKind)
+	}
+
+	unsupported := []*Query{
+		New().WithDialect(colonDialect{}).Select("id").From("jobs").ForUpdate(),
+		New().WithDialect(colonDialect{}).DeleteFrom("jobs").Returning("id"),
+		New().WithDialect(colonDialect{}).InsertInto("jobs", "id").Values(1).OnConflictDoNothing("id"),
+		New().WithDialect(colonDialect{}).InsertInto("jobs", "id").Values(1).InsertIgnore(),
+		New().WithDialect(colonDialect{}).Select("id").From(WithOrdinality(FuncTable("unnest", Col("tags")), "t")),
+	}
+
+	for _, q := range unsupported {
+		assertBuildError(t, q, ErrUnsupportedByDialect)
+	}
+}
+
+func TestBuiltInIdentifierQuoting(t *testing.T) {
+	cases := map[Dialect]string{
+		DialectMySQL:    "`weird``name`",
+		DialectPostgres: `"weird` + "`" + `name"`,
+		DialectSQLite:   `"weird` + "`" + `name"`,
+	}
+
+	for dialect, want := range cases {
+		if got := dialect.QuoteIdentifier("weird`name"); got != want {
+			t.Fatalf("%s: unexpected quoting. want %s got %s", dialect.Kind(), want, got)
+		}
+	}
+
+	if got := DialectPostgres.QuoteIdentifier(`say "hi"`); got != `"say ""hi"""` {
+		t.Fatalf("unexpected escaped identifier: %s", got)
+	}
+}
+
+func TestSQLServerPagination(t *testing.T) {
+	assertBuild(t,
+		New().WithDialect(DialectSQLServer).Select("id", "name").From("users").Where(Col("active").Eq(true)).Limit(10),
+		"SELECT TOP (10) id, name FROM users WHERE (active = @p1)",
+		[]any{true},
+	)
+
+	assertBuild(t,
+		New().WithDialect(DialectSQLServer).Select("email").Distinct().From("users").Limit(5),
+		"SELECT DISTINCT TOP (5) email FROM users",
+		nil,
+	)
+
+	assertBuild(t,
+		New().WithDialect(DialectSQLServer).Select("id").From("users").OrderBy("id DESC").Limit(10).Offset(20),
+		"SELECT id FROM users ORDER BY id DESC OFFSET 20 ROWS FETCH NEXT 10 ROWS ONLY",
+		nil,
+	)
+
+	assertBuild(t,
+		New().WithDialect(DialectSQLServer).Select("id").From("users").Offset(5),
+		"SELECT id FROM users ORDER BY (SELECT NULL) OFFSET 5 ROWS",
+		nil,
+	)
+
+	assertBuild(t,
+		New().WithDialect(DialectSQLServer).
+			Select("id").From("users").
+			UnionAll(New().Select("id").From("admins")).
+			OrderBy("id").Limit(3),
+		"SELECT id FROM users UNION ALL (SELECT id FROM admins) ORDER BY id OFFSET 0 ROWS FETCH NEXT 3 ROWS ONLY",
+		nil,
+	)
+}
+
+func TestSQLServerOutput(t *testing.T) {
+	assertBuild(t,
+		New().WithDialect(DialectSQLServer).InsertInto("users", "email", "name").Values("a@example.com", "Ana").Returning("id", "*"),
+		"INSERT INTO users (email, name) OUTPUT INSERTED.id, INSERTED.* VALUES (@p1, @p2)",
+		[]any{"a@example.com", "Ana"},
+	)
+
+	assertBuild(t,
+		New().WithDialect(DialectSQLServer).Update("users u").Set(Set("name", "Bia")).Where(Col("u.id").Eq(7)).
+			Returning(ColAlias("u.name", "new_name")),
+		"UPDATE users u SET name = @p1 OUTPUT INSERTED.name AS new_name WHERE (u.id = @p2)",
+		[]any{"Bia", 7},
+	)
+
+	assertBuild(t,
+		New().WithDialect(DialectSQLServer).DeleteFrom("sessions").Where(Col("expired").Eq(true)).Returning("id", Raw("GETDATE()")),
+		"DELETE FROM sessions OUTPUT DELETED.id, GETDATE() WHERE (expired = @p1)",
+		[]any{true},
+	)
+}
+
+func TestSQLServerLocksAndUnsupportedClauses(t *testing.T) {
+	assertBuild(t,
+		New().WithDialect(DialectSQLServer).Select("id").From(TableAlias("jobs", "j")).Where(Col("j.status").Eq("queued")).Limit(1).ForUpdate(),
+		"SELECT TOP (1) id FROM jobs AS j WITH (UPDLOCK, ROWLOCK) WHERE (j.status = @p1)",
+		[]any{"queued"},
+	)
+
+	assertBuild(t,
+		New().WithDialect(DialectSQLServer).Select("id").From("jobs").LockInShareMode(),
+		"SELECT id FROM jobs WITH (HOLDLOCK, ROWLOCK)",
+		nil,
+	)
+
+	if got := DialectSQLServer.QuoteIdentifier("odd]name"); got != "[odd]]name]" {
+		t.Fatalf("unexpected quoting: %s", got)
+	}
+
+	unsupported := []*Query{
+		New().WithDialect(DialectSQLServer).InsertInto("jobs", "id").Values(1).OnConflictDoNothing("id"),
+		New().WithDialect(DialectSQLServer).InsertInto("jobs", "id").Values(1).InsertIgnore(),
+		New().WithDialect(DialectSQLServer).Select(JSONExtract("payload", "$.id")).From("jobs"),
+	}
+
+	for _, q := range unsupported {
+		assertBuildError(t, q, ErrUnsupportedByDialect)
+	}
+}
+
+func TestOracleSelect(t *testing.T) {
+	assertBuild(t,
+		New().WithDialect(DialectOracle).
+			Select("u.id", "u.name").
+			From(TableAlias("users", "u")).
+			Where(Col("u.status").Eq("active")).
+			OrderBy("u.id").
+			Limit(10).
+			Offset(20),
+		"SELECT u.id, u.name FROM users u WHERE (u.status = :1) ORDER BY u.id OFFSET 20 ROWS FETCH FIRST 10 ROWS ONLY",
+		[]any{"active"},
+	)
+
+	assertBuild(t,
+		New().WithDialect(DialectOracle).Select("id").From("jobs").Where(Col("status").Eq("queued")).ForUpdate().SkipLocked(),
+		"SELECT id FROM jobs WHERE (status = :1) FOR UPDATE SKIP LOCKED",
+		[]any{"queued"},
+	)
+
+	assertBuildError(t, New().WithDialect(DialectOracle).Select("id").From("jobs").LockInShareMode(), ErrUnsupportedByDialect)
+}
+
+func TestOracleReturningInto(t *testing.T) {
+	var id int64
+
+	q := New().WithDialect(DialectOracle).
+		InsertInto("users", "email").
+		Values("a@example.com").
+		Returning("id").
+		ReturningInto(&id)
+
+	sqlStr, args, err := q.BuildContext(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if want := "INSERT INTO users (email) VALUES (:1) RETURNING id INTO :2"; sqlStr != want {
+		t.Fatalf("unexpected SQL.\nwant: %s\ngot:  %s", want, sqlStr)
+	}
+
+	if out, ok := args[1].(sql.Out); !ok || out.Dest != &id {
+		t.Fatalf("expected sql.Out bound to destination, got %#v", args[1])
+	}
+
+	assertBuildError(t,
+		New().WithDialect(DialectOracle).DeleteFrom("users").Where(Col("id").Eq(1)).Returning("id", "email").ReturningInto(&id),
+		ErrReturningDestinations,
+	)
+
+	assertBuild(t,
+		New().WithDialect(DialectPostgres).DeleteFrom("users").Returning("id").ReturningInto(&id),
+		"DELETE FROM users RETURNING id",
+		nil,
+	)
+}
+
+func TestOracleMergeUpsert(t *testing.T) {
+	assertBuild(t,
+		New().WithDialect(DialectOracle).
+			InsertInto("users", "email", "name").
+			Values("a@example.com", "Ana").
+			Values("b@example.com", "Bia").
+			OnConflictDoUpdate([]string{"email"}, Set("name", Raw("EXCLUDED.name"))),
+		"MERGE INTO users USING (SELECT :1 AS email, :2 AS name FROM dual UNION ALL SELECT :3 AS email, :4 AS name FROM dual) excluded "+
+			"ON (users.email = excluded.email) WHEN MATCHED THEN UPDATE SET name = EXCLUDED.name "+
+			"WHEN NOT MATCHED THEN INSERT (email, name) VALUES (excluded.email, excluded.name)",
+		[]any{"a@example.com", "Ana", "b@example.com", "Bia"},
+	)
+
+	assertBuild(t,
+		New().WithDialect(DialectOracle).
+			InsertInto(TableAlias("users", "u"), "email").
+			Values("a@example.com").
+			OnConflictDoNothing("email"),
+		"MERGE INTO users u USING (SELECT :1 AS email FROM dual) excluded ON (u.email = excluded.email) "+
+			"WHEN NOT MATCHED THEN INSERT (email) VALUES (excluded.email)",
+		[]any{"a@example.com"},
+	)
+
+	assertBuild(t,
+		New().WithDialect(DialectOracle).
+			InsertInto("users", "email", "version").
+			Values("a@example.com", 2).
+			OnConflictDoUpdate([]string{"email"}, Set("version", Excluded("version"))).
+			OnConflictUpdateWhere(Col("users.version").Lt(Excluded("version"))),
+		"MERGE INTO users USING (SELECT :1 AS email, :2 AS version FROM dual) excluded ON (users.email = excluded.email) "+
+			"WHEN MATCHED THEN UPDATE SET version = excluded.version WHERE (users.version < excluded.version) "+
+			"WHEN NOT MATCHED THEN INSERT (email, version) VALUES (excluded.email, excluded.version)",
+		[]any{"a@example.com", 2},
+	)
+
+	assertBuildError(t,
+		New().WithDialect(DialectOracle).InsertInto("users", "email").Values("a@example.com").OnConflictDoNothing(),
+		ErrUnsupportedByDialect,
+	)
+}
+
+func TestLockModifiers(t *testing.T) {
+	assertBuild(t,
+		New().WithDialect(DialectPostgres).Select("id").From("jobs").LockInShareMode().NoWait(),
+		"SELECT id FROM jobs FOR SHARE NOWAIT",
+		nil,
+	)
+
+	assertBuild(t,
+		New().WithDialect(DialectMySQL).Select("id").From("jobs").LockInShareMode().SkipLocked(),
+		"SELECT id FROM jobs FOR SHARE SKIP LOCKED",
+		nil,
+	)
+
+	assertBuild(t,
+		New().WithDialect(DialectSQLServer).Select("id").From("jobs").SkipLocked().ForUpdate(),
+		"SELECT id FROM jobs WITH (UPDLOCK, ROWLOCK, READPAST)",
+		nil,
+	)
+
+	assertBuildError(t, New().Select("id").From("jobs").SkipLocked(), ErrInvalidClause)
+	assertBuildError(t, New().WithDialect(DialectSQLite).Select("id").From("jobs").ForUpdate().NoWait(), ErrUnsupportedByDialect)
+}
+
+func TestClickHouseAnalytics(t *testing.T) {
+	assertBuild(t,
+		New().WithDialect(DialectClickHouse).
+			Select("region", "channel", Raw("sum(amount) AS total")).
+			From(TableAlias("sales", "s").Final().Sample(0.1)).
+			Where(Col("day").Gte("2025-01-01")).
+			GroupBy(Rollup("region", "channel")).
+			Setting("max_threads", 8).
+			Setting("join_algorithm", "hash"),
+		"SELECT region, channel, sum(amount) AS total FROM sales AS s FINAL SAMPLE 0.1 WHERE (day >= ?) "+
+			"GROUP BY region, channel WITH ROLLUP SETTINGS max_threads = 8, join_algorithm = 'hash'",
+		[]any{"2025-01-01"},
+	)
+
+	assertBuild(t,
+		New().WithDialect(DialectClickHouse).
+			Select("domain", "path", "hits").
+			From("pageviews").
+			GroupBy(Cube("domain", "path")).
+			OrderBy("hits DESC").
+			LimitBy(3, "domain").
+			Limit(100),
+		"SELECT domain, path, hits FROM pageviews GROUP BY domain, path WITH CUBE ORDER BY hits DESC LIMIT 3 BY domain LIMIT 100",
+		nil,
+	)
+
+	assertBuild(t,
+		New().WithDialect(DialectClickHouse).
+			Select("a", "b").
+			From("t").
+			GroupBy(GroupingSets(GroupSet("a"), GroupSet("b"))),
+		"SELECT a, b FROM t GROUP BY GROUPING SETS ((a), (b))",
+		nil,
+	)
+}
+
+func TestClickHouseRejectsUnsupportedClauses(t *testing.T) {
+	unsupported := []*Query{
+		New().WithDialect(DialectClickHouse).Select("id").From("events").ForUpdate(),
+		New().WithDialect(DialectClickHouse).InsertInto("events", "id").Values(1).Returning("id"),
+		New().WithDialect(DialectClickHouse).InsertInto("events", "id").Values(1).OnConflictDoNothing("id"),
+		New().WithDialect(DialectPostgres).Select("id").From(TableAlias("events", "e").Final()),
+		New().WithDialect(DialectMySQL).Select("id").From("events").LimitBy(1, "user_id"),
+		New().WithDialect(DialectSQLite).Select("id").From("events").Setting("max_threads", 1),
+	}
+
+	for _, q := range unsupported {
+		assertBuildError(t, q, ErrUnsupportedByDialect)
+	}
+
+	assertBuildError(t,
+		New().WithDialect(DialectClickHouse).Select("id").From("events").Setting("max threads", 1),
+		ErrInvalidSetting,
+	)
+	assertBuildError(t,
+		New().WithDialect(DialectClickHouse).Select("id").From("events").Setting("max_threads", []int{1}),
+		ErrInvalidSetting,
+	)
+}
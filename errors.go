@@ -0,0 +1,121 @@
+package chizuql
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	// ErrEmptyInList reports an IN/NOT IN predicate built without values.
+	ErrEmptyInList = errors.New("chizuql: IN list cannot be empty")
+	// ErrMissingSetClause reports an UPDATE without SET assignments.
+	ErrMissingSetClause = errors.New("chizuql: UPDATE requires at least one SET clause")
+	// ErrMissingInsertTable reports an INSERT whose target table was never configured with InsertInto.
+	ErrMissingInsertTable = errors.New("chizuql: InsertInto must be called before building INSERT queries")
+	// ErrMissingQueryType reports a query built before Select/InsertInto/Update/DeleteFrom was called.
+	ErrMissingQueryType = errors.New("chizuql: query type not set")
+	// ErrUnsupportedByDialect reports a feature that the configured dialect cannot render.
+	ErrUnsupportedByDialect = errors.New("chizuql: feature not supported by dialect")
+	// ErrInvalidCursor reports a keyset pagination cursor that does not match the ORDER BY configuration.
+	ErrInvalidCursor = errors.New("chizuql: invalid keyset cursor")
+	// ErrInvalidClause reports a clause used with a query type that does not accept it (ex: RETURNING on SELECT).
+	ErrInvalidClause = errors.New("chizuql: clause not allowed for query type")
+	// ErrConflictingClauses reports clauses that cannot be combined in the same statement.
+	ErrConflictingClauses = errors.New("chizuql: conflicting clauses")
+	// ErrEmptyGrouping reports GROUPING SETS, ROLLUP or CUBE elements without expressions.
+	ErrEmptyGrouping = errors.New("chizuql: grouping element requires at least one expression")
+	// ErrNilQuery reports a nil *Query used as an operand or subquery.
+	ErrNilQuery = errors.New("chizuql: nil query")
+	// ErrUnsupportedTable reports a value that cannot be converted into a table expression.
+	ErrUnsupportedTable = errors.New("chizuql: unsupported table expression")
+	// ErrReturningDestinations reports a RETURNING ... INTO clause whose destinations do not match the returned
+	// expressions.
+	ErrReturningDestinations = errors.New("chizuql: RETURNING INTO requires one destination per returned expression")
+	// ErrInvalidSetting reports a SETTINGS entry with an invalid name or a value that cannot be rendered inline.
+	ErrInvalidSetting = errors.New("chizuql: invalid query setting")
+	// ErrInvalidIdentifier reports a plain string that is not a valid identifier while strict identifiers are enabled.
+	ErrInvalidIdentifier = errors.New("chizuql: invalid identifier")
+	// ErrPlaceholderMismatch reports a raw fragment whose ? markers do not match the number of arguments.
+	ErrPlaceholderMismatch = errors.New("chizuql: placeholder count does not match arguments")
+	// ErrNamedArgMismatch reports a named marker without a value, a named value never referenced, or named and
+	// positional arguments mixed in the same fragment.
+	ErrNamedArgMismatch = errors.New("chizuql: named arguments do not match markers")
+	// ErrUnmappedColumn reports a result column without a matching destination while scanning rows into a type.
+	ErrUnmappedColumn = errors.New("chizuql: result column has no matching field")
+	// ErrInvalidStruct reports a value passed to the struct-driven INSERT and UPDATE helpers that is not a struct, a
+	// struct pointer or a non-empty slice of structs of the same type.
+	ErrInvalidStruct = errors.New("chizuql: invalid struct value")
+	// ErrMismatchedColumns reports a VALUES row whose keys differ from the INSERT column list.
+	ErrMismatchedColumns = errors.New("chizuql: row columns do not match INSERT columns")
+	// ErrColumnNotAllowed reports an INSERT or UPDATE that writes a column outside the AllowColumns list.
+	ErrColumnNotAllowed = errors.New("chizuql: column not allowed")
+	// ErrPlaceholderLimit reports an INSERT row, or a statement that cannot be split, that needs more placeholders than
+	// a BuildBatches statement allows.
+	ErrPlaceholderLimit = errors.New("chizuql: placeholder limit exceeded")
+	// ErrMissingJoinCondition reports a join without ON or USING condition while strict joins are enabled.
+	ErrMissingJoinCondition = errors.New("chizuql: join requires a condition")
+)
+
+// BuildError describes a validation failure detected while building a query.
+//
+// Err holds one of the package sentinel errors, so callers can branch with errors.Is while still inspecting the clause,
+// query type and dialect involved through errors.As.
+type BuildError struct {
+	// Clause names the clause or feature that failed (ex: "IN", "RETURNING", "MATCH ... AGAINST").
+	Clause string
+	// QueryType is the statement kind being built (SELECT, INSERT, UPDATE, DELETE or RAW), when known.
+	QueryType string
+	// Dialect is the dialect used for rendering, when known.
+	Dialect DialectKind
+	// Detail carries additional context about the failure.
+	Detail string
+	// Err is the sentinel error describing the failure category.
+	Err error
+}
+
+func newBuildError(clause string, err error, detail string) *BuildError {
+	return &BuildError{Clause: clause, Err: err, Detail: detail}
+}
+
+// Error renders the sentinel message followed by the clause, detail, query type and dialect.
+func (e *BuildError) Error() string {
+	sb := strings.Builder{}
+
+	if e.Err != nil {
+		sb.WriteString(e.Err.Error())
+	} else {
+		sb.WriteString("chizuql: build failed")
+	}
+
+	if e.Clause != "" {
+		sb.WriteString(": ")
+		sb.WriteString(e.Clause)
+	}
+
+	if e.Detail != "" {
+		sb.WriteString(": ")
+		sb.WriteString(e.Detail)
+	}
+
+	meta := make([]string, 0, 2)
+
+	if e.QueryType != "" {
+		meta = append(meta, fmt.Sprintf("query %s", e.QueryType))
+	}
+
+	if e.Dialect != "" {
+		meta = append(meta, fmt.Sprintf("dialect %s", e.Dialect))
+	}
+
+	if len(meta) > 0 {
+		sb.WriteString(" (")
+		sb.WriteString(strings.Join(meta, ", "))
+		sb.WriteString(")")
+	}
+
+	return sb.String()
+}
+
+// Unwrap exposes the sentinel error for errors.Is.
+func (e *BuildError) Unwrap() error { return e.Err }
@@ -0,0 +1,72 @@
+package chizuql
+
+import (
+	"context"
+	"database/sql"
+)
+
+// Executor runs SQL statements against a database. *sql.DB, *sql.Tx and *sql.Conn satisfy it.
+type Executor interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+// QueryContext builds the query with BuildContext and runs it on db, returning the resulting rows.
+//
+// Build errors are returned before reaching the database; hooks and the query dialect apply as in BuildContext.
+func (q *Query) QueryContext(ctx context.Context, db Executor) (*sql.Rows, error) {
+	query, args, err := q.BuildContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return db.QueryContext(ctx, query, args...)
+}
+
+// QueryRowContext builds the query with BuildContext and runs it on db, expecting at most one row.
+//
+// Build errors are deferred until Row.Scan or Row.Err is called, mirroring *sql.Row.
+func (q *Query) QueryRowContext(ctx context.Context, db Executor) *Row {
+	query, args, err := q.BuildContext(ctx)
+	if err != nil {
+		return &Row{err: err}
+	}
+
+	return &Row{row: db.QueryRowContext(ctx, query, args...)}
+}
+
+// ExecContext builds the query with BuildContext and executes it on db without returning rows.
+func (q *Query) ExecContext(ctx context.Context, db Executor) (sql.Result, error) {
+	query, args, err := q.BuildContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return db.ExecContext(ctx, query, args...)
+}
+
+// Row is the result of Query.QueryRowContext. It wraps *sql.Row and also carries build errors.
+type Row struct {
+	row *sql.Row
+	err error
+}
+
+// Scan copies the columns of the matched row into dest. It returns the build error, if any, or sql.ErrNoRows when
+// the query matched no rows.
+func (r *Row) Scan(dest ...any) error {
+	if r.err != nil {
+		return r.err
+	}
+
+	return r.row.Scan(dest...)
+}
+
+// Err returns the build or query error without scanning the row.
+func (r *Row) Err() error {
+	if r.err != nil {
+		return r.err
+	}
+
+	return r.row.Err()
+}
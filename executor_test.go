@@ -0,0 +1,223 @@
+package chizuql
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"reflect"
+	"testing"
+)
+
+type fakeCall struct {
+	query string
+	args  []any
+}
+
+// fakeDB is an in-memory database/sql driver that records statements and replies with canned rows.
+type fakeDB struct {
+	calls        []fakeCall
+	columns      []string
+	rows         [][]driver.Value
+	rowsAffected int64
+}
+
+func (f *fakeDB) open(t *testing.T) *sql.DB {
+	t.Helper()
+
+	db := sql.OpenDB(fakeConnector{db: f})
+	t.Cleanup(func() { _ = db.Close() })
+
+	return db
+}
+
+func (f *fakeDB) record(query string, args []driver.NamedValue) {
+	values := make([]any, 0, len(args))
+	for _, arg := range args {
+		values = append(values, arg.Value)
+	}
+
+	f.calls = append(f.calls, fakeCall{query: query, args: values})
+}
+
+func (f *fakeDB) lastCall(t *testing.T) fakeCall {
+	t.Helper()
+
+	if len(f.calls) == 0 {
+		t.Fatalf("expected a statement to reach the database")
+	}
+
+	return f.calls[len(f.calls)-1]
+}
+
+type fakeConnector struct{ db *fakeDB }
+
+func (c fakeConnector) Connect(context.Context) (driver.Conn, error) { return fakeConn(c), nil }
+func (c fakeConnector) Driver() driver.Driver                        { return fakeDriver{} }
+
+type fakeDriver struct{}
+
+func (fakeDriver) Open(string) (driver.Conn, error) { return nil, errors.New("use fakeConnector") }
+
+type fakeConn struct{ db *fakeDB }
+
+func (c fakeConn) Prepare(string) (driver.Stmt, error) { return nil, errors.New("unsupported") }
+func (c fakeConn) Close() error                        { return nil }
+func (c fakeConn) Begin() (driver.Tx, error)           { return fakeTx{}, nil }
+
+func (c fakeConn) QueryContext(_ context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	c.db.record(query, args)
+
+	return &fakeRows{columns: c.db.columns, rows: c.db.rows}, nil
+}
+
+func (c fakeConn) ExecContext(_ context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	c.db.record(query, args)
+
+	return driver.RowsAffected(c.db.rowsAffected), nil
+}
+
+type fakeTx struct{}
+
+func (fakeTx) Commit() error   { return nil }
+func (fakeTx) Rollback() error { return nil }
+
+type fakeRows struct {
+	columns []string
+	rows    [][]driver.Value
+	next    int
+}
+
+func (r *fakeRows) Columns() []string { return r.columns }
+func (r *fakeRows) Close() error      { return nil }
+
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if r.next >= len(r.rows) {
+		return io.EOF
+	}
+
+	copy(dest, r.rows[r.next])
+	r.next++
+
+	return nil
+}
+
+func TestQueryContextRunsBuiltSQL(t *testing.T) {
+	fake := &fakeDB{columns: []string{"id", "email"}, rows: [][]driver.Value{{int64(1), "a@example.com"}, {int64(2), "b@example.com"}}}
+	db := fake.open(t)
+
+	hookCalls := 0
+	q := New().
+		WithDialect(DialectPostgres).
+		WithHooks(BuildHookFuncs{After: func(context.Context, BuildResult) error {
+			hookCalls++
+
+			return nil
+		}}).
+		Select("id", "email").
+		From("users").
+		Where(Col("active").Eq(true))
+
+	rows, err := q.QueryContext(context.Background(), db)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	defer func() { _ = rows.Close() }()
+
+	emails := make([]string, 0)
+
+	for rows.Next() {
+		var (
+			id    int64
+			email string
+		)
+
+		if err := rows.Scan(&id, &email); err != nil {
+			t.Fatalf("scan failed: %v", err)
+		}
+
+		emails = append(emails, email)
+	}
+
+	if err := rows.Err(); err != nil {
+		t.Fatalf("rows error: %v", err)
+	}
+
+	call := fake.lastCall(t)
+	if call.query != "SELECT id, email FROM users WHERE (active = $1)" || !reflect.DeepEqual(call.args, []any{true}) {
+		t.Fatalf("unexpected statement: %+v", call)
+	}
+
+	if !reflect.DeepEqual(emails, []string{"a@example.com", "b@example.com"}) || hookCalls != 1 {
+		t.Fatalf("unexpected results: %v (hooks=%d)", emails, hookCalls)
+	}
+}
+
+func TestQueryRowAndExecContext(t *testing.T) {
+	fake := &fakeDB{columns: []string{"count"}, rows: [][]driver.Value{{int64(42)}}, rowsAffected: 3}
+	db := fake.open(t)
+	ctx := context.Background()
+
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		t.Fatalf("conn: %v", err)
+	}
+
+	defer func() { _ = conn.Close() }()
+
+	var count int
+
+	if err := New().Select(Raw("COUNT(*)")).From("users").QueryRowContext(ctx, conn).Scan(&count); err != nil || count != 42 {
+		t.Fatalf("unexpected row: %d, %v", count, err)
+	}
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		t.Fatalf("begin: %v", err)
+	}
+
+	result, err := New().WithDialect(DialectPostgres).Update("users").Set(Set("active", false)).Where(Col("id").In(1, 2, 3)).ExecContext(ctx, tx)
+	if err != nil {
+		t.Fatalf("exec: %v", err)
+	}
+
+	if affected, _ := result.RowsAffected(); affected != 3 {
+		t.Fatalf("unexpected rows affected: %d", affected)
+	}
+
+	if call := fake.lastCall(t); call.query != "UPDATE users SET active = $1 WHERE (id IN ($2, $3, $4))" {
+		t.Fatalf("unexpected statement: %s", call.query)
+	}
+
+	_ = tx.Commit()
+}
+
+func TestExecutionStopsOnBuildErrors(t *testing.T) {
+	fake := &fakeDB{}
+	db := fake.open(t)
+	ctx := context.Background()
+	invalid := New().Update("users")
+
+	if _, err := invalid.ExecContext(ctx, db); !errors.Is(err, ErrMissingSetClause) {
+		t.Fatalf("expected build error from ExecContext, got %v", err)
+	}
+
+	if _, err := invalid.QueryContext(ctx, db); !errors.Is(err, ErrMissingSetClause) {
+		t.Fatalf("expected build error from QueryContext, got %v", err)
+	}
+
+	row := invalid.QueryRowContext(ctx, db)
+	if err := row.Err(); !errors.Is(err, ErrMissingSetClause) {
+		t.Fatalf("expected build error from Row.Err, got %v", err)
+	}
+
+	if err := row.Scan(new(int)); !errors.Is(err, ErrMissingSetClause) {
+		t.Fatalf("expected build error from Row.Scan, got %v", err)
+	}
+
+	if len(fake.calls) != 0 {
+		t.Fatalf("invalid queries must not reach the database: %+v", fake.calls)
+	}
+}
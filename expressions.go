@@ -2,21 +2,11 @@ package chizuql
 
 import (
 	"fmt"
+	"reflect"
 	"strings"
 	"sync"
 )
 
-func requireDialect(ctx *buildContext, expected dialectKind, feature string) {
-	kind, ok := dialectKindOf(ctx.dialect)
-	if !ok {
-		panic(fmt.Sprintf("%s requer um dialeto reconhecido", feature))
-	}
-
-	if kind != expected {
-		panic(fmt.Sprintf("%s é suportado apenas no dialeto %s", feature, expected))
-	}
-}
-
 func escapeSingleQuotes(value string) string {
 	return strings.ReplaceAll(value, "'", "''")
 }
@@ -70,10 +60,10 @@ func ColAlias(name, alias string) Column {
 
 func (c Column) build(ctx *buildContext) string {
 	if c.alias != "" {
-		return fmt.Sprintf("%s AS %s", c.name, c.alias)
+		return fmt.Sprintf("%s AS %s", ctx.quoteIdentifier(c.name), ctx.quoteIdentifier(c.alias))
 	}
 
-	return c.name
+	return ctx.quoteIdentifier(c.name)
 }
 
 func (c Column) Eq(value any) Predicate {
@@ -106,10 +96,6 @@ func (c Column) NotIn(values ...any) Predicate {
 }
 
 func (c Column) buildInPredicate(negate bool, values ...any) Predicate {
-	if len(values) == 0 {
-		panic("IN list cannot be empty")
-	}
-
 	if len(values) == 1 {
 		op := "IN"
 		if negate {
@@ -129,6 +115,48 @@ func (c Column) buildInPredicate(negate bool, values ...any) Predicate {
 	return inPredicate{left: c, list: exprs, negate: negate}
 }
 
+// EqAny builds an = ANY predicate against a subquery, a slice or an array expression.
+//
+// Subqueries render op ANY (SELECT ...) with their placeholders numbered in sequence with the outer query. Slices and
+// other values render op ANY($1) with a single array argument on dialects with array quantifiers (PostgreSQL); the
+// driver must accept the slice as an array (wrap it with pq.Array on lib/pq). Elsewhere EqAny and NeAll fall back to
+// IN and NOT IN, and the other quantified comparisons fail with ErrUnsupportedByDialect. Scalar operands fail with
+// ErrInvalidClause.
+func (c Column) EqAny(value any) Predicate { return newQuantified(c, "=", "ANY", value) }
+
+// NeAny builds a <> ANY predicate. See EqAny.
+func (c Column) NeAny(value any) Predicate { return newQuantified(c, "<>", "ANY", value) }
+
+// GtAny builds a > ANY predicate. See EqAny.
+func (c Column) GtAny(value any) Predicate { return newQuantified(c, ">", "ANY", value) }
+
+// GteAny builds a >= ANY predicate. See EqAny.
+func (c Column) GteAny(value any) Predicate { return newQuantified(c, ">=", "ANY", value) }
+
+// LtAny builds a < ANY predicate. See EqAny.
+func (c Column) LtAny(value any) Predicate { return newQuantified(c, "<", "ANY", value) }
+
+// LteAny builds a <= ANY predicate. See EqAny.
+func (c Column) LteAny(value any) Predicate { return newQuantified(c, "<=", "ANY", value) }
+
+// EqAll builds an = ALL predicate. See EqAny.
+func (c Column) EqAll(value any) Predicate { return newQuantified(c, "=", "ALL", value) }
+
+// NeAll builds a <> ALL predicate. See EqAny.
+func (c Column) NeAll(value any) Predicate { return newQuantified(c, "<>", "ALL", value) }
+
+// GtAll builds a > ALL predicate. See EqAny.
+func (c Column) GtAll(value any) Predicate { return newQuantified(c, ">", "ALL", value) }
+
+// GteAll builds a >= ALL predicate. See EqAny.
+func (c Column) GteAll(value any) Predicate { return newQuantified(c, ">=", "ALL", value) }
+
+// LtAll builds a < ALL predicate. See EqAny.
+func (c Column) LtAll(value any) Predicate { return newQuantified(c, "<", "ALL", value) }
+
+// LteAll builds a <= ALL predicate. See EqAny.
+func (c Column) LteAll(value any) Predicate { return newQuantified(c, "<=", "ALL", value) }
+
 // Between builds a BETWEEN predicate.
 func (c Column) Between(start, end any) Predicate {
 	return betweenPredicate{left: c, start: toValueExpression(start), end: toValueExpression(end)}
@@ -168,21 +196,113 @@ func (v valueExpr) build(ctx *buildContext) string {
 	return ctx.nextPlaceholder(v.value)
 }
 
+type excludedExpr struct {
+	column string
+}
+
+// Excluded references a column of the row proposed for insertion inside conflict handlers, keeping one upsert
+// definition portable: EXCLUDED.column on PostgreSQL and SQLite, excluded.column on MERGE-based upserts, and
+// VALUES(column) or the excluded row alias on MySQL (see MySQLExcludedMode).
+func Excluded(column string) Expression { return excludedExpr{column: column} }
+
+func (e excludedExpr) build(ctx *buildContext) string {
+	column := ctx.quoteIdentifier(e.column)
+
+	switch ctx.features.Upsert {
+	case UpsertOnConflict:
+		return "EXCLUDED." + column
+	case UpsertMerge:
+		return "excluded." + column
+	case UpsertOnDuplicateKey:
+		if ctx.rowAlias {
+			return "excluded." + column
+		}
+
+		return fmt.Sprintf("VALUES(%s)", column)
+	default:
+		ctx.addError(newBuildError("EXCLUDED", ErrUnsupportedByDialect, ""))
+
+		return ""
+	}
+}
+
+type defaultExpr struct{}
+
+// Default renders the DEFAULT keyword, asking the database to use the column default in a Values row or a Set
+// assignment. Used anywhere else, including nested in another expression, it fails with ErrInvalidClause. Dialects
+// without the keyword (ex: SQLite) fail with ErrUnsupportedByDialect.
+func Default() Expression { return defaultExpr{} }
+
+// build is reached only when Default is used outside a written value; buildWrittenValue renders the valid uses.
+func (defaultExpr) build(ctx *buildContext) string {
+	ctx.addError(newBuildError("DEFAULT", ErrInvalidClause, "only allowed as a VALUES item or a SET value"))
+
+	return "DEFAULT"
+}
+
+// buildWrittenValue renders a value written to a column by a VALUES row or a SET assignment, the only places where
+// Default is accepted.
+func buildWrittenValue(ctx *buildContext, value Expression) string {
+	if _, ok := value.(defaultExpr); ok {
+		requireFeature(ctx, ctx.features.DefaultKeyword, "DEFAULT")
+
+		return "DEFAULT"
+	}
+
+	return value.build(ctx)
+}
+
 // Raw builds an expression that is inserted as-is. Arguments are appended verbatim.
 type rawExpr struct {
-	sql  string
-	args []any
+	sql   string
+	args  []any
+	named map[string]any
+	// bindNames marks fragments whose markers are bound by name, even when the map of values is empty.
+	bindNames bool
+	// implicit marks plain strings converted by toSQLExpression, which are quoted when they look like identifiers.
+	implicit bool
 }
 
 // Raw creates a raw SQL expression. Use carefully.
+//
+// Each ? marker outside string literals, quoted identifiers and comments is rendered with the dialect placeholder and
+// numbered in sequence with the surrounding query; write ?? for a literal ?, with or without args. The number of
+// markers must match the number of args, otherwise the build fails with ErrPlaceholderMismatch.
 func Raw(sql string, args ...any) Expression {
+	named, err := namedArgs("Raw", args)
+	if err != nil {
+		return invalidExpr{err: err}
+	}
+
+	if named != nil {
+		return rawExpr{sql: sql, named: named, bindNames: true}
+	}
+
 	return rawExpr{sql: sql, args: args}
 }
 
+// RawNamed creates a raw SQL expression whose :name or @name markers are bound from args.
+//
+// Markers are rendered with the dialect placeholders in sequence with the surrounding query; numbered dialects reuse
+// the same placeholder for repeated names. :: casts, @@ variables and markers inside string literals, quoted
+// identifiers and comments are kept as-is. Names without a value and values never referenced fail the build with
+// ErrNamedArgMismatch. Raw accepts sql.NamedArg arguments with the same semantics.
+func RawNamed(sql string, args map[string]any) Expression {
+	return rawExpr{sql: sql, named: args, bindNames: true}
+}
+
 func (r rawExpr) build(ctx *buildContext) string {
-	ctx.args = append(ctx.args, r.args...)
+	if r.implicit {
+		ctx.args = append(ctx.args, r.args...)
+
+		return ctx.quoteImplicit(r.sql)
+	}
+
+	if r.bindNames {
+		return rewriteNamedPlaceholders(ctx, "Raw", r.sql, r.named)
+	}
 
-	return r.sql
+	return rewritePlaceholders(ctx, "Raw", r.sql, r.args)
 }
 
 // subqueryExpr is used to embed subqueries into larger expressions.
@@ -194,6 +314,17 @@ func (s subqueryExpr) build(ctx *buildContext) string {
 	return fmt.Sprintf("(%s)", s.query.render(ctx))
 }
 
+// invalidExpr defers a construction failure so it is reported when the query is built.
+type invalidExpr struct {
+	err error
+}
+
+func (i invalidExpr) build(ctx *buildContext) string {
+	ctx.addError(i.err)
+
+	return ""
+}
+
 // comparison represents a binary comparison predicate.
 type comparison struct {
 	left  Expression
@@ -214,7 +345,14 @@ type inPredicate struct {
 
 func (i inPredicate) build(ctx *buildContext) string {
 	if len(i.list) == 0 {
-		panic("IN list cannot be empty")
+		clause := "IN"
+		if i.negate {
+			clause = "NOT IN"
+		}
+
+		ctx.addError(newBuildError(clause, ErrEmptyInList, ""))
+
+		return ""
 	}
 
 	parts := make([]string, 0, len(i.list))
@@ -301,6 +439,103 @@ func (n notPredicate) build(ctx *buildContext) string {
 	return fmt.Sprintf("NOT (%s)", n.pred.build(ctx))
 }
 
+// Exists builds an EXISTS predicate over a subquery, usually correlated with the outer query.
+func Exists(query *Query) Predicate { return existsPredicate{query: query} }
+
+// NotExists builds a NOT EXISTS predicate over a subquery.
+func NotExists(query *Query) Predicate { return existsPredicate{query: query, negate: true} }
+
+type existsPredicate struct {
+	query  *Query
+	negate bool
+}
+
+func (e existsPredicate) build(ctx *buildContext) string {
+	keyword := "EXISTS"
+	if e.negate {
+		keyword = "NOT EXISTS"
+	}
+
+	if e.query == nil {
+		ctx.addError(newBuildError(keyword, ErrNilQuery, ""))
+
+		return ""
+	}
+
+	return keyword + " " + subqueryExpr{query: e.query}.build(ctx)
+}
+
+// quantifiedPredicate compares a value with every row of a subquery or element of an array (op ANY/ALL).
+type quantifiedPredicate struct {
+	left       Expression
+	op         string
+	quantifier string
+	right      Expression
+	// list holds the elements of a slice operand, used by the IN fallback.
+	list []Expression
+	// scalar marks an operand that is neither a slice, a subquery nor an expression.
+	scalar bool
+}
+
+func newQuantified(left Expression, op, quantifier string, value any) Predicate {
+	p := quantifiedPredicate{left: left, op: op, quantifier: quantifier, right: toValueExpression(value)}
+
+	if _, ok := value.([]byte); !ok && value != nil && reflect.TypeOf(value).Kind() == reflect.Slice {
+		slice := reflect.ValueOf(value)
+		p.list = make([]Expression, 0, slice.Len())
+
+		for i := range slice.Len() {
+			p.list = append(p.list, toValueExpression(slice.Index(i).Interface()))
+		}
+	}
+
+	_, expr := value.(Expression)
+	_, subquery := p.right.(subqueryExpr)
+	p.scalar = p.list == nil && !expr && !subquery
+
+	return p
+}
+
+func (p quantifiedPredicate) build(ctx *buildContext) string {
+	syntax := ctx.features.Quantifiers
+	sub, subquery := p.right.(subqueryExpr)
+	clause := p.op + " " + p.quantifier
+
+	// = ANY and <> ALL are equivalent to IN and NOT IN, which every dialect accepts.
+	fallback := (p.op == "=" && p.quantifier == "ANY") || (p.op == "<>" && p.quantifier == "ALL")
+
+	switch {
+	case subquery && sub.query == nil:
+		ctx.addError(newBuildError(clause, ErrNilQuery, ""))
+
+		return ""
+	case p.scalar:
+		ctx.addError(newBuildError(clause, ErrInvalidClause, "operand must be a subquery, a slice or an expression"))
+
+		return ""
+	case subquery && syntax != QuantifiersUnsupported:
+		return fmt.Sprintf("%s %s %s", p.left.build(ctx), clause, p.right.build(ctx))
+	case !subquery && syntax == QuantifiersArray:
+		return fmt.Sprintf("%s %s(%s)", p.left.build(ctx), clause, p.right.build(ctx))
+	case subquery && fallback:
+		return comparison{left: p.left, op: inKeyword(p.op == "<>"), right: p.right}.build(ctx)
+	case p.list != nil && fallback:
+		return inPredicate{left: p.left, list: p.list, negate: p.op == "<>"}.build(ctx)
+	default:
+		requireFeature(ctx, false, clause)
+
+		return ""
+	}
+}
+
+func inKeyword(negate bool) string {
+	if negate {
+		return "NOT IN"
+	}
+
+	return "IN"
+}
+
 // MatchBuilder creates MySQL MATCH ... AGAINST predicates.
 type MatchBuilder struct {
 	columns []string
@@ -340,13 +575,16 @@ type matchScoreExpr struct {
 }
 
 func (m matchAgainstExpr) build(ctx *buildContext) string {
-	requireDialect(ctx, dialectMySQL, "MATCH ... AGAINST")
+	if !requireFeature(ctx, ctx.features.TextSearch == TextSearchMatchAgainst, "MATCH ... AGAINST") {
+		return ""
+	}
 
 	pl := ctx.nextPlaceholder(m.query)
-	part := fmt.Sprintf("MATCH(%s) AGAINST (%s)", strings.Join(m.columns, ", "), pl)
+	columns := ctx.quoteIdentifierList(m.columns)
+	part := fmt.Sprintf("MATCH(%s) AGAINST (%s)", columns, pl)
 
 	if m.mode != "" {
-		part = fmt.Sprintf("MATCH(%s) AGAINST (%s IN %s)", strings.Join(m.columns, ", "), pl, m.mode)
+		part = fmt.Sprintf("MATCH(%s) AGAINST (%s IN %s)", columns, pl, m.mode)
 	}
 
 	return part
@@ -415,23 +653,25 @@ func (t tsQueryPredicate) build(ctx *buildContext) string {
 	return fmt.Sprintf("%s @@ %s", vector, query)
 }
 
-func (t TsVectorBuilder) concatColumns() string {
+func (t TsVectorBuilder) concatColumns(ctx *buildContext) string {
 	switch len(t.columns) {
 	case 0:
 		return "''"
 	case 1:
-		return t.columns[0]
+		return ctx.quoteIdentifier(t.columns[0])
 	default:
-		return fmt.Sprintf("CONCAT_WS(' ', %s)", strings.Join(t.columns, ", "))
+		return fmt.Sprintf("CONCAT_WS(' ', %s)", ctx.quoteIdentifierList(t.columns))
 	}
 }
 
 func (t TsVectorBuilder) buildTsQuery(ctx *buildContext, query string, mode string) (string, string) {
-	requireDialect(ctx, dialectPostgres, "Full Text Search (tsvector)")
+	if !requireFeature(ctx, ctx.features.TextSearch == TextSearchTsVector, "Full Text Search (tsvector)") {
+		return "", ""
+	}
 
 	placeholder := ctx.nextPlaceholder(query)
 	config := escapeSingleQuotes(t.config)
-	vector := fmt.Sprintf("to_tsvector('%s', %s)", config, t.concatColumns())
+	vector := fmt.Sprintf("to_tsvector('%s', %s)", config, t.concatColumns(ctx))
 
 	switch mode {
 	case "web":
@@ -626,34 +866,12 @@ type jsonExtractExpr struct {
 }
 
 func (j jsonExtractExpr) build(ctx *buildContext) string {
-	kind, ok := dialectKindOf(ctx.dialect)
-	if !ok {
-		panic("Extração de JSON requer um dialeto reconhecido")
-	}
-
-	path := j.path.build(ctx)
-
-	var expr string
-
-	switch kind {
-	case dialectMySQL:
-		expr = fmt.Sprintf("JSON_EXTRACT(%s, %s)", j.column, path)
-	case dialectPostgres:
-		expr = fmt.Sprintf("jsonb_path_query_first(to_jsonb(%s), (%s)::jsonpath)", j.column, path)
-	default:
-		panic("Extração de JSON não suportada para este dialeto")
-	}
-
-	if j.unwrap {
-		switch kind {
-		case dialectMySQL:
-			return fmt.Sprintf("JSON_UNQUOTE(%s)", expr)
-		case dialectPostgres:
-			return fmt.Sprintf("(%s)::text", expr)
-		}
+	renderer := ctx.features.JSON
+	if !requireFeature(ctx, renderer != nil, "JSON_EXTRACT") {
+		return ""
 	}
 
-	return expr
+	return renderer.JSONExtract(ctx.quoteIdentifier(j.column), j.path.build(ctx), j.unwrap)
 }
 
 // JSONContains builds a containment predicate for JSON/JSONB values.
@@ -667,21 +885,12 @@ type jsonContainsPredicate struct {
 }
 
 func (j jsonContainsPredicate) build(ctx *buildContext) string {
-	kind, ok := dialectKindOf(ctx.dialect)
-	if !ok {
-		panic("JSON_CONTAINS requer um dialeto reconhecido")
+	renderer := ctx.features.JSON
+	if !requireFeature(ctx, renderer != nil, "JSON_CONTAINS") {
+		return ""
 	}
 
-	value := j.value.build(ctx)
-
-	switch kind {
-	case dialectMySQL:
-		return fmt.Sprintf("JSON_CONTAINS(%s, %s)", j.column, value)
-	case dialectPostgres:
-		return fmt.Sprintf("to_jsonb(%s) @> (%s)::jsonb", j.column, value)
-	default:
-		panic("JSON_CONTAINS não suportado para este dialeto")
-	}
+	return renderer.JSONContains(ctx.quoteIdentifier(j.column), j.value.build(ctx))
 }
 
 // GroupingSet represents a grouping set clause.
@@ -701,19 +910,11 @@ func GroupingSets(sets ...GroupingSet) Expression {
 
 // Rollup builds a ROLLUP grouping element.
 func Rollup(expressions ...any) Expression {
-	if len(expressions) == 0 {
-		panic("ROLLUP requer ao menos uma expressão")
-	}
-
 	return rollupExpr{elements: toSQLExpressions(expressions...)}
 }
 
 // Cube builds a CUBE grouping element.
 func Cube(expressions ...any) Expression {
-	if len(expressions) == 0 {
-		panic("CUBE requer ao menos uma expressão")
-	}
-
 	return cubeExpr{elements: toSQLExpressions(expressions...)}
 }
 
@@ -723,7 +924,9 @@ type groupingSetsExpr struct {
 
 func (g groupingSetsExpr) build(ctx *buildContext) string {
 	if len(g.sets) == 0 {
-		panic("GROUPING SETS requer ao menos um agrupamento")
+		ctx.addError(newBuildError("GROUPING SETS", ErrEmptyGrouping, ""))
+
+		return ""
 	}
 
 	parts := make([]string, 0, len(g.sets))
@@ -744,6 +947,12 @@ type rollupExpr struct {
 }
 
 func (r rollupExpr) build(ctx *buildContext) string {
+	if len(r.elements) == 0 {
+		ctx.addError(newBuildError("ROLLUP", ErrEmptyGrouping, ""))
+
+		return ""
+	}
+
 	parts := make([]string, 0, len(r.elements))
 	for _, e := range r.elements {
 		parts = append(parts, e.build(ctx))
@@ -757,6 +966,12 @@ type cubeExpr struct {
 }
 
 func (c cubeExpr) build(ctx *buildContext) string {
+	if len(c.elements) == 0 {
+		ctx.addError(newBuildError("CUBE", ErrEmptyGrouping, ""))
+
+		return ""
+	}
+
 	parts := make([]string, 0, len(c.elements))
 	for _, e := range c.elements {
 		parts = append(parts, e.build(ctx))
@@ -820,7 +1035,7 @@ func parseRawOrdering(raw rawExpr) (Expression, string, bool) {
 
 	direction := strings.ToUpper(tokens[dirIdx])
 
-	return rawExpr{sql: baseSQL, args: raw.args}, direction, true
+	return rawExpr{sql: baseSQL, args: raw.args, implicit: raw.implicit}, direction, true
 }
 
 // KeysetAfter builds a keyset pagination predicate for moving forward (next page) using the provided ORDER BY expressions.
@@ -841,11 +1056,12 @@ func KeysetBefore(ordering []Expression, cursorValues ...any) Predicate {
 
 func buildKeysetPredicate(ordering []Expression, cursorValues []any, forward bool) Predicate {
 	if len(ordering) == 0 {
-		panic("keyset pagination requer ao menos uma expressão de ordenação")
+		return invalidExpr{err: newBuildError("KEYSET", ErrInvalidCursor, "at least one ordering expression is required")}
 	}
 
 	if len(ordering) != len(cursorValues) {
-		panic("a quantidade de valores de cursor deve corresponder ao ORDER BY configurado")
+		return invalidExpr{err: newBuildError("KEYSET", ErrInvalidCursor,
+			fmt.Sprintf("expected %d cursor values, got %d", len(ordering), len(cursorValues)))}
 	}
 
 	values := toValueExpressions(cursorValues...)
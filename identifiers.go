@@ -0,0 +1,179 @@
+package chizuql
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// identifierParts splits a possibly qualified identifier (ex: schema.table.column) into its parts. It reports false
+// when any part is not a plain identifier; a trailing * is accepted as the last part.
+func identifierParts(name string) ([]string, bool) {
+	if name == "" {
+		return nil, false
+	}
+
+	parts := strings.Split(name, ".")
+	for i, part := range parts {
+		if part == "*" && i == len(parts)-1 {
+			continue
+		}
+
+		if !isPlainIdentifier(part) {
+			return nil, false
+		}
+	}
+
+	return parts, true
+}
+
+// isPlainIdentifier reports whether s is an unquoted SQL identifier made of letters, digits, _ and $ that does not
+// start with a digit or $.
+func isPlainIdentifier(s string) bool {
+	if s == "" {
+		return false
+	}
+
+	for i, r := range s {
+		switch {
+		case r == '_', r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z':
+		case i > 0 && (r == '$' || r >= '0' && r <= '9'):
+		default:
+			return false
+		}
+	}
+
+	return true
+}
+
+// IdentifierQuoting configures whether table and column names are quoted with the dialect quote characters.
+type IdentifierQuoting int
+
+const (
+	// IdentifierQuotingNone writes identifiers verbatim.
+	IdentifierQuotingNone IdentifierQuoting = iota
+	// IdentifierQuotingAll quotes every identifier part (ex: schema.table.column becomes "schema"."table"."column").
+	// Names that are not plain identifiers, such as expressions or already quoted names, are written verbatim, and * is
+	// never quoted. Fragments built with Raw are not affected.
+	IdentifierQuotingAll
+)
+
+var (
+	defaultIdentifierQuoting   = IdentifierQuotingNone
+	defaultIdentifierQuotingMu sync.RWMutex
+)
+
+// SetDefaultIdentifierQuoting replaces the package-wide identifier quoting mode used by newly created queries.
+func SetDefaultIdentifierQuoting(mode IdentifierQuoting) {
+	defaultIdentifierQuotingMu.Lock()
+	defer defaultIdentifierQuotingMu.Unlock()
+
+	defaultIdentifierQuoting = mode
+}
+
+// DefaultIdentifierQuoting returns the package-wide identifier quoting mode.
+func DefaultIdentifierQuoting() IdentifierQuoting {
+	defaultIdentifierQuotingMu.RLock()
+	defer defaultIdentifierQuotingMu.RUnlock()
+
+	return defaultIdentifierQuoting
+}
+
+var (
+	defaultStrictIdentifiers   bool
+	defaultStrictIdentifiersMu sync.RWMutex
+)
+
+// SetDefaultStrictIdentifiers enables or disables strict identifiers (see Query.WithStrictIdentifiers) for newly
+// created queries.
+func SetDefaultStrictIdentifiers(enabled bool) {
+	defaultStrictIdentifiersMu.Lock()
+	defer defaultStrictIdentifiersMu.Unlock()
+
+	defaultStrictIdentifiers = enabled
+}
+
+// DefaultStrictIdentifiers reports whether newly created queries use strict identifiers.
+func DefaultStrictIdentifiers() bool {
+	defaultStrictIdentifiersMu.RLock()
+	defer defaultStrictIdentifiersMu.RUnlock()
+
+	return defaultStrictIdentifiers
+}
+
+// quoteIdentifier quotes each part of a possibly qualified identifier when quoting is enabled.
+func (ctx *buildContext) quoteIdentifier(name string) string {
+	if ctx.quoting != IdentifierQuotingAll {
+		return name
+	}
+
+	parts, ok := identifierParts(name)
+	if !ok {
+		return name
+	}
+
+	for i, part := range parts {
+		if part != "*" {
+			parts[i] = ctx.dialect.QuoteIdentifier(part)
+		}
+	}
+
+	return strings.Join(parts, ".")
+}
+
+// quoteIdentifierList quotes and joins a list of column names.
+func (ctx *buildContext) quoteIdentifierList(names []string) string {
+	quoted := make([]string, 0, len(names))
+	for _, name := range names {
+		quoted = append(quoted, ctx.quoteIdentifier(name))
+	}
+
+	return strings.Join(quoted, ", ")
+}
+
+// quoteImplicit quotes plain strings passed where expressions are expected (Select, OrderBy, GroupBy...). Only
+// identifiers, optionally followed by ASC or DESC, are quoted; other fragments are written verbatim, or rejected with
+// ErrInvalidIdentifier in strict mode.
+func (ctx *buildContext) quoteImplicit(sql string) string {
+	name, direction, ok := splitOrderedIdentifier(sql)
+	if !ok {
+		if ctx.strict {
+			ctx.addError(newBuildError("identifier", ErrInvalidIdentifier, fmt.Sprintf("%q is not an identifier; use Raw", sql)))
+		}
+
+		return sql
+	}
+
+	if ctx.quoting != IdentifierQuotingAll {
+		return sql
+	}
+
+	if direction == "" {
+		return ctx.quoteIdentifier(name)
+	}
+
+	return ctx.quoteIdentifier(name) + " " + direction
+}
+
+// splitOrderedIdentifier parses "identifier" or "identifier ASC|DESC", returning the identifier and the upper-cased
+// direction.
+func splitOrderedIdentifier(sql string) (string, string, bool) {
+	tokens := strings.Fields(sql)
+
+	switch {
+	case len(tokens) == 1:
+	case len(tokens) == 2 && (strings.EqualFold(tokens[1], "ASC") || strings.EqualFold(tokens[1], "DESC")):
+	default:
+		return "", "", false
+	}
+
+	if _, ok := identifierParts(tokens[0]); !ok {
+		return "", "", false
+	}
+
+	if len(tokens) == 1 {
+		return tokens[0], "", true
+	}
+
+	return tokens[0], strings.ToUpper(tokens[1]), true
+}
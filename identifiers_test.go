@@ -0,0 +1,141 @@
+package chizuql
+
+import "testing"
+
+func TestIdentifierQuotingPerDialect(t *testing.T) {
+	build := func(d Dialect) *Query {
+		return New().
+			WithDialect(d).
+			WithIdentifierQuoting(IdentifierQuotingAll).
+			Select("o.*", "o.order", ColAlias("u.user", "buyer"), Raw("COUNT(*) AS total")).
+			From(TableAlias("job.search", "o")).
+			Join("users u", Raw("u.id = o.user_id")).
+			Where(Col("o.status").Eq("paid")).
+			GroupBy("o.order", "u.user").
+			OrderBy("o.order DESC")
+	}
+
+	assertBuild(t, build(DialectMySQL),
+		"SELECT `o`.*, `o`.`order`, `u`.`user` AS `buyer`, COUNT(*) AS total FROM `job`.`search` AS `o` "+
+			"JOIN users u ON (u.id = o.user_id) WHERE (`o`.`status` = ?) GROUP BY `o`.`order`, `u`.`user` ORDER BY `o`.`order` DESC",
+		[]any{"paid"},
+	)
+
+	assertBuild(t, build(DialectPostgres),
+		`SELECT "o".*, "o"."order", "u"."user" AS "buyer", COUNT(*) AS total FROM "job"."search" AS "o" `+
+			`JOIN users u ON (u.id = o.user_id) WHERE ("o"."status" = $1) GROUP BY "o"."order", "u"."user" ORDER BY "o"."order" DESC`,
+		[]any{"paid"},
+	)
+}
+
+func TestIdentifierQuotingDML(t *testing.T) {
+	assertBuild(t,
+		New().WithDialect(DialectSQLite).WithIdentifierQuoting(IdentifierQuotingAll).
+			With("recent", New().Select("id").From("order").Limit(5), "id").
+			InsertInto("order", "user", "group").
+			Values(1, "a").
+			OnConflictDoUpdate([]string{"user"}, Set("group", Raw("excluded.group"))).
+			Returning("id"),
+		`WITH "recent" ("id") AS (SELECT "id" FROM "order" LIMIT 5) INSERT INTO "order" ("user", "group") VALUES (?, ?) `+
+			`ON CONFLICT ("user") DO UPDATE SET "group" = excluded.group RETURNING "id"`,
+		[]any{1, "a"},
+	)
+
+	assertBuild(t,
+		New().WithDialect(DialectSQLServer).WithIdentifierQuoting(IdentifierQuotingAll).
+			Update("user").Set(Set("order", 2)).Where(Col("id").Eq(1)).Returning("order"),
+		"UPDATE [user] SET [order] = @p1 OUTPUT INSERTED.[order] WHERE ([id] = @p2)",
+		[]any{2, 1},
+	)
+}
+
+func TestDefaultIdentifierQuoting(t *testing.T) {
+	SetDefaultIdentifierQuoting(IdentifierQuotingAll)
+	t.Cleanup(func() { SetDefaultIdentifierQuoting(IdentifierQuotingNone) })
+
+	if DefaultIdentifierQuoting() != IdentifierQuotingAll {
+		t.Fatalf("expected global quoting mode to be updated")
+	}
+
+	assertBuild(t,
+		New().WithDialect(DialectPostgres).Select("id", `"Mixed"`, "lower(name)").From("users"),
+		`SELECT "id", "Mixed", lower(name) FROM "users"`,
+		nil,
+	)
+
+	assertBuild(t,
+		New().WithDialect(DialectPostgres).WithIdentifierQuoting(IdentifierQuotingNone).Select("id").From("users"),
+		"SELECT id FROM users",
+		nil,
+	)
+}
+
+func TestStrictIdentifiersRejectRawStrings(t *testing.T) {
+	sortField := "created_at; DROP TABLE users"
+
+	assertBuildError(t,
+		New().WithStrictIdentifiers().Select("id").From("users").OrderBy(sortField),
+		ErrInvalidIdentifier,
+	)
+
+	unsafe := []*Query{
+		New().WithStrictIdentifiers().Select("COUNT(*) AS total").From("users"),
+		New().WithStrictIdentifiers().Select("region").From("sales").GroupBy("region, channel"),
+		New().WithStrictIdentifiers().Select(Func("COALESCE", "name", "'anon'")).From("users"),
+		New().WithStrictIdentifiers().WithDialect(DialectPostgres).DeleteFrom("users").Returning("id, email"),
+		New().WithStrictIdentifiers().Select("id").From("users").OrderBy("id DESC NULLS LAST"),
+	}
+
+	for _, q := range unsafe {
+		assertBuildError(t, q, ErrInvalidIdentifier)
+	}
+}
+
+func TestStrictIdentifiersSurviveNesting(t *testing.T) {
+	strict := func() *Query {
+		return New().WithStrictIdentifiers().Select("id").From("users").GroupBy("id; DROP TABLE users --")
+	}
+
+	nested := []*Query{
+		New().Select("*").From(FromSubquery(strict(), "x")),
+		New().Select("id").From("x").Where(Exists(strict())),
+		New().With("picked", strict()).Select("*").From("picked"),
+		New().Select("id").From("x").Union(strict()),
+	}
+
+	for _, q := range nested {
+		assertBuildError(t, q, ErrInvalidIdentifier)
+	}
+
+	assertBuildError(t,
+		New().Select("*").From(FromSubquery(New().WithStrictIdentifiers().Select("id").From("users").OrderBy("id; DROP TABLE users --"), "x")),
+		ErrInvalidIdentifier,
+	)
+
+	assertBuild(t,
+		New().Select("*").From(FromSubquery(New().WithStrictIdentifiers().Select("id").From("users"), "x")).OrderBy("random()"),
+		"SELECT * FROM (SELECT id FROM users) AS x ORDER BY random()",
+		nil,
+	)
+}
+
+func TestStrictIdentifiersAcceptIdentifiersAndRaw(t *testing.T) {
+	assertBuild(t,
+		New().WithStrictIdentifiers().
+			Select("u.*", "o.total", Raw("COUNT(*) AS orders"), Func("COALESCE", "u.name", Value("anon"))).
+			From(TableAlias("users", "u")).
+			GroupBy("u.id").
+			OrderBy("o.total desc", "u.id"),
+		"SELECT u.*, o.total, COUNT(*) AS orders, COALESCE(u.name, ?) FROM users AS u GROUP BY u.id ORDER BY o.total desc, u.id",
+		[]any{"anon"},
+	)
+
+	SetDefaultStrictIdentifiers(true)
+	t.Cleanup(func() { SetDefaultStrictIdentifiers(false) })
+
+	if !DefaultStrictIdentifiers() {
+		t.Fatalf("expected strict identifiers to be enabled globally")
+	}
+
+	assertBuildError(t, New().Select("id").From("users").OrderBy("random()"), ErrInvalidIdentifier)
+}
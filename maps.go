@@ -0,0 +1,163 @@
+package chizuql
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// SetMap adds SET clauses for each entry of values, sorted by column name so the rendered SQL is stable across calls.
+//
+// Keys must be plain, optionally dotted, identifiers whatever the strict identifier setting; any other key fails the
+// build with ErrInvalidIdentifier. Combine it with AllowColumns when the map comes from client input.
+func (q *Query) SetMap(values map[string]any) *Query {
+	for _, column := range sortedKeys(values) {
+		if !q.checkMapKey("SET", column) {
+			continue
+		}
+
+		q.setClauses = append(q.setClauses, Set(column, values[column]))
+	}
+
+	return q
+}
+
+// ValuesMap appends one VALUES row per map for an INSERT query.
+//
+// When InsertInto was called without columns, the sorted keys of the first row become the column list; otherwise
+// values follow the declared columns. Every row must have exactly the same keys as the column list, or the build
+// fails with ErrMismatchedColumns. As with SetMap, keys that are not plain identifiers fail with ErrInvalidIdentifier.
+func (q *Query) ValuesMap(rows ...map[string]any) *Query {
+	for i, row := range rows {
+		valid := true
+
+		for _, key := range sortedKeys(row) {
+			valid = q.checkMapKey("VALUES", key) && valid
+		}
+
+		if !valid {
+			continue
+		}
+
+		if len(q.insertCols) == 0 {
+			q.insertCols = sortedKeys(row)
+		}
+
+		if detail := mismatchedColumns(q.insertCols, row); detail != "" {
+			q.addError(newBuildError("VALUES", ErrMismatchedColumns, fmt.Sprintf("row %d: %s", i, detail)))
+
+			continue
+		}
+
+		values := make([]Expression, 0, len(q.insertCols))
+		for _, column := range q.insertCols {
+			values = append(values, toValueExpression(row[column]))
+		}
+
+		q.insertValues = append(q.insertValues, values)
+	}
+
+	return q
+}
+
+// checkMapKey records ErrInvalidIdentifier when a map key cannot be used as a column name. Map keys often come from
+// client input, so they are validated even when strict identifiers are off.
+func (q *Query) checkMapKey(clause, key string) bool {
+	if _, ok := identifierParts(key); ok && !strings.HasSuffix(key, "*") {
+		return true
+	}
+
+	q.addError(newBuildError(clause, ErrInvalidIdentifier, fmt.Sprintf("%q is not a column name", key)))
+
+	return false
+}
+
+// AllowColumns restricts the columns INSERT and UPDATE queries may write, including ON CONFLICT assignments. Writing
+// any other column fails the build with ErrColumnNotAllowed, which keeps maps built from client input (ex: PATCH
+// payloads) from naming arbitrary columns. Calling it again extends the allow-list.
+func (q *Query) AllowColumns(columns ...string) *Query {
+	if q.allowedColumns == nil {
+		q.allowedColumns = make(map[string]struct{}, len(columns))
+	}
+
+	for _, column := range columns {
+		q.allowedColumns[column] = struct{}{}
+	}
+
+	return q
+}
+
+// checkAllowedColumns reports the written columns missing from the allow-list configured with AllowColumns.
+func (q *Query) checkAllowedColumns(ctx *buildContext) {
+	if q.allowedColumns == nil {
+		return
+	}
+
+	written := slices.Clone(q.insertCols)
+	for _, clauses := range [][]SetClause{q.setClauses, q.onConflictSet} {
+		for _, clause := range clauses {
+			written = append(written, clause.column)
+		}
+	}
+
+	rejected := make([]string, 0)
+
+	for _, column := range written {
+		if _, ok := q.allowedColumns[column]; !ok && !slices.Contains(rejected, column) {
+			rejected = append(rejected, column)
+		}
+	}
+
+	if len(rejected) > 0 {
+		sort.Strings(rejected)
+		ctx.addError(newBuildError("ALLOW COLUMNS", ErrColumnNotAllowed, strings.Join(rejected, ", ")))
+	}
+}
+
+// mismatchedColumns describes the differences between the row keys and the expected columns, or returns an empty
+// string when they match.
+func mismatchedColumns(columns []string, row map[string]any) string {
+	if len(row) == 0 {
+		return "empty row"
+	}
+
+	missing := make([]string, 0)
+
+	for _, column := range columns {
+		if _, ok := row[column]; !ok {
+			missing = append(missing, column)
+		}
+	}
+
+	extra := make([]string, 0)
+
+	for _, key := range sortedKeys(row) {
+		if !slices.Contains(columns, key) {
+			extra = append(extra, key)
+		}
+	}
+
+	parts := make([]string, 0, 2)
+
+	if len(missing) > 0 {
+		parts = append(parts, "missing "+strings.Join(missing, ", "))
+	}
+
+	if len(extra) > 0 {
+		parts = append(parts, "unexpected "+strings.Join(extra, ", "))
+	}
+
+	return strings.Join(parts, "; ")
+}
+
+func sortedKeys(values map[string]any) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
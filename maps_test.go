@@ -0,0 +1,90 @@
+package chizuql
+
+import "testing"
+
+func TestSetMapSortsColumns(t *testing.T) {
+	patch := map[string]any{"name": "Ana", "email": "ana@example.com", "updated_at": Raw("NOW()")}
+
+	q := New().WithDialect(DialectPostgres).Update("users").SetMap(patch).Where(Col("id").Eq(1))
+
+	for range 3 {
+		assertBuild(t, q,
+			"UPDATE users SET email = $1, name = $2, updated_at = NOW() WHERE (id = $3)",
+			[]any{"ana@example.com", "Ana", 1},
+		)
+	}
+
+	assertBuildError(t, New().Update("users").SetMap(nil), ErrMissingSetClause)
+}
+
+func TestValuesMap(t *testing.T) {
+	q := New().WithDialect(DialectPostgres).
+		InsertInto("users").
+		ValuesMap(
+			map[string]any{"name": "Ana", "email": "ana@example.com"},
+			map[string]any{"email": "bia@example.com", "name": "Bia"},
+		)
+
+	assertBuild(t, q,
+		"INSERT INTO users (email, name) VALUES ($1, $2), ($3, $4)",
+		[]any{"ana@example.com", "Ana", "bia@example.com", "Bia"},
+	)
+
+	declared := New().InsertInto("users", "name", "email").ValuesMap(map[string]any{"email": "ana@example.com", "name": "Ana"})
+
+	assertBuild(t, declared,
+		"INSERT INTO users (name, email) VALUES (?, ?)",
+		[]any{"Ana", "ana@example.com"},
+	)
+
+	assertBuildError(t,
+		New().InsertInto("users").ValuesMap(map[string]any{"name": "Ana"}, map[string]any{"name": "Bia", "admin": true}),
+		ErrMismatchedColumns,
+	)
+
+	assertBuildError(t,
+		New().InsertInto("users", "name", "email").ValuesMap(map[string]any{"name": "Ana"}),
+		ErrMismatchedColumns,
+	)
+
+	assertBuildError(t, New().InsertInto("users").ValuesMap(map[string]any{}), ErrMismatchedColumns)
+}
+
+func TestAllowColumns(t *testing.T) {
+	allowed := New().Update("users").AllowColumns("name", "email").SetMap(map[string]any{"name": "Ana"})
+
+	assertBuild(t, allowed, "UPDATE users SET name = ?", []any{"Ana"})
+
+	assertBuildError(t,
+		New().Update("users").SetMap(map[string]any{"name": "Ana", "is_admin": true}).AllowColumns("name", "email"),
+		ErrColumnNotAllowed,
+	)
+
+	assertBuildError(t,
+		New().AllowColumns("email").InsertInto("users").ValuesMap(map[string]any{"email": "a@example.com", "role": "admin"}),
+		ErrColumnNotAllowed,
+	)
+
+	assertBuildError(t,
+		New().WithDialect(DialectPostgres).
+			AllowColumns("email").
+			InsertInto("users", "email").
+			Values("a@example.com").
+			OnConflictDoUpdate([]string{"email"}, Set("role", "admin")),
+		ErrColumnNotAllowed,
+	)
+}
+
+func TestMapKeysMustBeIdentifiers(t *testing.T) {
+	injected := map[string]any{"role = 'admin', name": "x"}
+
+	assertBuildError(t, New().Update("users").SetMap(injected).Where(Col("id").Eq(1)), ErrInvalidIdentifier)
+	assertBuildError(t, New().WithStrictIdentifiers().Update("users").SetMap(injected), ErrInvalidIdentifier)
+	assertBuildError(t, New().InsertInto("t").ValuesMap(map[string]any{"a) SELECT 1; --": 1}), ErrInvalidIdentifier)
+	assertBuildError(t, New().InsertInto("t", "a").ValuesMap(map[string]any{"a": 1}, map[string]any{"*": 2}), ErrInvalidIdentifier)
+
+	assertBuild(t, New().Update("users u").SetMap(map[string]any{"u.name": "Ana"}),
+		"UPDATE users u SET u.name = ?",
+		[]any{"Ana"},
+	)
+}
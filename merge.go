@@ -0,0 +1,355 @@
+package chizuql
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+)
+
+type mergeAction int
+
+const (
+	mergeUpdate mergeAction = iota
+	mergeDelete
+	mergeDoNothing
+	mergeInsert
+)
+
+// mergeArm is a WHEN [NOT] MATCHED branch of a MERGE statement.
+type mergeArm struct {
+	matched   bool
+	condition Predicate
+	action    mergeAction
+	set       []SetClause
+	columns   []string
+	values    []Expression
+}
+
+// MergeMatched configures the action of a WHEN MATCHED arm. Create it with Query.WhenMatched.
+type MergeMatched struct {
+	query     *Query
+	condition Predicate
+}
+
+// MergeNotMatched configures the action of a WHEN NOT MATCHED arm. Create it with Query.WhenNotMatched.
+type MergeNotMatched struct {
+	query     *Query
+	condition Predicate
+}
+
+// Merge starts a MERGE statement that reconciles the target table with the rows configured in Using.
+//
+// PostgreSQL 15+, SQL Server and Oracle render a native MERGE. MySQL and SQLite fall back to INSERT ... SELECT with ON
+// DUPLICATE KEY UPDATE or ON CONFLICT when the arms allow it: exactly one unconditional WHEN NOT MATCHED THEN INSERT
+// arm and at most one unconditional WHEN MATCHED arm that updates or does nothing. The fallback relies on a unique key
+// matching the ON condition, drops the target alias, and reads update values that repeat an inserted value from the
+// proposed row. Other arm combinations, and dialects without either syntax, fail with ErrUnsupportedByDialect.
+func (q *Query) Merge(into any) *Query {
+	q.qType = queryTypeMerge
+	q.mergeTable = toTableExpression(into)
+
+	return q
+}
+
+// Using sets the MERGE source (a table, TableRef or FromSubquery) and the predicates, combined with AND, that match
+// source rows with target rows.
+func (q *Query) Using(source any, on ...Predicate) *Query {
+	q.mergeSource = toTableExpression(source)
+	q.mergeOn = combinePredicates(q.mergeOn, on)
+
+	return q
+}
+
+// WhenMatched starts a WHEN MATCHED arm, restricted to rows matching the optional conditions (combined with AND).
+func (q *Query) WhenMatched(conditions ...Predicate) MergeMatched {
+	return MergeMatched{query: q, condition: combinePredicates(nil, conditions)}
+}
+
+// WhenNotMatched starts a WHEN NOT MATCHED arm, restricted to source rows matching the optional conditions (combined
+// with AND).
+func (q *Query) WhenNotMatched(conditions ...Predicate) MergeNotMatched {
+	return MergeNotMatched{query: q, condition: combinePredicates(nil, conditions)}
+}
+
+// Update updates matched target rows with the given assignments.
+func (m MergeMatched) Update(clauses ...SetClause) *Query {
+	return m.query.addMergeArm(mergeArm{matched: true, condition: m.condition, action: mergeUpdate, set: clauses})
+}
+
+// Delete deletes matched target rows.
+func (m MergeMatched) Delete() *Query {
+	return m.query.addMergeArm(mergeArm{matched: true, condition: m.condition, action: mergeDelete})
+}
+
+// DoNothing leaves matched target rows untouched.
+func (m MergeMatched) DoNothing() *Query {
+	return m.query.addMergeArm(mergeArm{matched: true, condition: m.condition, action: mergeDoNothing})
+}
+
+// Insert inserts a target row for each unmatched source row. Values usually reference source columns.
+func (m MergeNotMatched) Insert(columns []string, values ...any) *Query {
+	if len(columns) != len(values) {
+		m.query.addError(newBuildError("WHEN NOT MATCHED", ErrMismatchedColumns,
+			fmt.Sprintf("%d columns, %d values", len(columns), len(values))))
+	}
+
+	return m.query.addMergeArm(mergeArm{
+		condition: m.condition,
+		action:    mergeInsert,
+		columns:   columns,
+		values:    toValueExpressions(values...),
+	})
+}
+
+// DoNothing skips unmatched source rows.
+func (m MergeNotMatched) DoNothing() *Query {
+	return m.query.addMergeArm(mergeArm{condition: m.condition, action: mergeDoNothing})
+}
+
+func (q *Query) addMergeArm(arm mergeArm) *Query {
+	q.mergeArms = append(q.mergeArms, arm)
+
+	return q
+}
+
+func (q *Query) buildMerge(sql *strings.Builder, ctx *buildContext) {
+	switch {
+	case q.mergeTable == nil || q.mergeSource == nil || q.mergeOn == nil:
+		ctx.addError(newBuildError("MERGE", ErrInvalidClause, "requires a target, Using source and ON predicates"))
+	case len(q.mergeArms) == 0:
+		ctx.addError(newBuildError("MERGE", ErrInvalidClause, "requires at least one WHEN arm"))
+	case len(q.returning) > 0:
+		ctx.addError(newBuildError("RETURNING", ErrInvalidClause, "not supported on MERGE"))
+	case ctx.features.Merge != MergeUnsupported:
+		q.buildNativeMerge(sql, ctx)
+	case ctx.features.Upsert == UpsertOnDuplicateKey || ctx.features.Upsert == UpsertOnConflict:
+		q.buildMergeFallback(sql, ctx)
+	default:
+		ctx.addError(newBuildError("MERGE", ErrUnsupportedByDialect, ""))
+	}
+}
+
+func (q *Query) buildNativeMerge(sql *strings.Builder, ctx *buildContext) {
+	sql.WriteString("MERGE ")
+	q.writeOptimizerHints(sql, ctx)
+	sql.WriteString("INTO ")
+	sql.WriteString(q.mergeTable.build(ctx))
+	sql.WriteString(" USING ")
+	sql.WriteString(q.mergeSource.build(ctx))
+	sql.WriteString(" ON ")
+	sql.WriteString(q.mergeOn.build(ctx))
+
+	if ctx.features.Merge == MergeWhereClauses {
+		q.writeMergeWhereArms(sql, ctx)
+
+		return
+	}
+
+	for _, arm := range q.mergeArms {
+		if arm.action == mergeDoNothing && ctx.features.Merge == MergeSemicolonTerminated {
+			continue
+		}
+
+		sql.WriteString(" ")
+		sql.WriteString(arm.when())
+
+		if arm.condition != nil {
+			sql.WriteString(" AND ")
+			sql.WriteString(arm.condition.build(ctx))
+		}
+
+		sql.WriteString(" THEN ")
+		sql.WriteString(arm.build(ctx))
+	}
+
+	if ctx.features.Merge == MergeSemicolonTerminated {
+		sql.WriteString(";")
+	}
+}
+
+// writeMergeWhereArms renders the arms of dialects that filter actions with WHERE (Oracle): one arm of each kind,
+// with DO NOTHING arms omitted and DELETE only available after an UPDATE.
+func (q *Query) writeMergeWhereArms(sql *strings.Builder, ctx *buildContext) {
+	seen := make(map[bool]bool, 2)
+
+	for _, arm := range q.mergeArms {
+		if seen[arm.matched] {
+			ctx.addError(newBuildError(arm.when(), ErrUnsupportedByDialect, "only one arm of each kind is supported"))
+
+			continue
+		}
+
+		seen[arm.matched] = true
+
+		switch arm.action {
+		case mergeDoNothing:
+			continue
+		case mergeDelete:
+			ctx.addError(newBuildError(arm.when(), ErrUnsupportedByDialect, "DELETE requires an UPDATE in the same arm"))
+
+			continue
+		}
+
+		sql.WriteString(" ")
+		sql.WriteString(arm.when())
+		sql.WriteString(" THEN ")
+		sql.WriteString(arm.build(ctx))
+		q.buildPredicates(sql, ctx, "WHERE", arm.condition)
+	}
+}
+
+func (a mergeArm) when() string {
+	if a.matched {
+		return "WHEN MATCHED"
+	}
+
+	return "WHEN NOT MATCHED"
+}
+
+func (a mergeArm) build(ctx *buildContext) string {
+	switch a.action {
+	case mergeUpdate:
+		if len(a.set) == 0 {
+			ctx.addError(newBuildError(a.when(), ErrMissingSetClause, ""))
+		}
+
+		return "UPDATE SET " + buildSetClauses(ctx, a.set)
+	case mergeDelete:
+		return "DELETE"
+	case mergeInsert:
+		return fmt.Sprintf("INSERT (%s) VALUES (%s)", ctx.quoteIdentifierList(a.columns), buildWrittenValueList(ctx, a.values))
+	default:
+		return "DO NOTHING"
+	}
+}
+
+// buildMergeFallback renders the MERGE as INSERT ... SELECT with the dialect conflict handler.
+func (q *Query) buildMergeFallback(sql *strings.Builder, ctx *buildContext) {
+	insertArm, matchedArm, detail := q.fallbackArms()
+	if detail != "" {
+		ctx.addError(newBuildError("MERGE", ErrUnsupportedByDialect, detail))
+
+		return
+	}
+
+	target := q.mergeTable
+	if ref, ok := target.(TableRef); ok && ref.sub == nil {
+		target = TableRef{name: ref.name}
+	}
+
+	insert := &Query{
+		qType:          queryTypeInsert,
+		insertTable:    target,
+		insertCols:     insertArm.columns,
+		insertSelect:   &Query{qType: queryTypeSelect, selectColumns: insertArm.values, from: q.mergeSource},
+		optimizerHints: q.optimizerHints,
+	}
+
+	switch {
+	case matchedArm != nil && matchedArm.action == mergeUpdate:
+		insert.onConflictSet = q.fallbackAssignments(ctx, insertArm, matchedArm.set)
+	case ctx.features.Upsert == UpsertOnDuplicateKey:
+		// ON DUPLICATE KEY has no DO NOTHING; a self-assignment keeps the existing row unchanged.
+		insert.onConflictSet = []SetClause{{column: insertArm.columns[0], value: Col(insertArm.columns[0])}}
+	default:
+		insert.onConflictDoNothing = true
+	}
+
+	insert.buildInsert(sql, ctx)
+}
+
+// fallbackArms returns the INSERT and optional WHEN MATCHED arms of a MERGE that can be rewritten as an upsert, or
+// a description of why it cannot.
+func (q *Query) fallbackArms() (*mergeArm, *mergeArm, string) {
+	var insertArm, matchedArm *mergeArm
+
+	for i := range q.mergeArms {
+		arm := &q.mergeArms[i]
+
+		switch {
+		case arm.condition != nil:
+			return nil, nil, "conditional arms require a native MERGE"
+		case arm.action == mergeDelete:
+			return nil, nil, "WHEN MATCHED THEN DELETE requires a native MERGE"
+		case !arm.matched && arm.action != mergeInsert:
+			return nil, nil, "WHEN NOT MATCHED THEN DO NOTHING requires a native MERGE"
+		case arm.matched && matchedArm != nil, !arm.matched && insertArm != nil:
+			return nil, nil, "multiple arms of the same kind require a native MERGE"
+		case arm.matched:
+			matchedArm = arm
+		default:
+			insertArm = arm
+		}
+	}
+
+	if insertArm == nil || len(insertArm.columns) == 0 {
+		return nil, nil, "the upsert fallback requires a WHEN NOT MATCHED THEN INSERT arm"
+	}
+
+	return insertArm, matchedArm, ""
+}
+
+// fallbackAssignments rewrites update values that repeat an inserted value as references to the proposed row, since
+// ON CONFLICT cannot read the source table. Any other value that still references the source or the target alias,
+// which the upsert drops, is reported as unsupported.
+func (q *Query) fallbackAssignments(ctx *buildContext, insertArm *mergeArm, set []SetClause) []SetClause {
+	qualifiers := []string{tableQualifier(q.mergeSource)}
+	if ref, ok := q.mergeTable.(TableRef); ok && ref.alias != "" {
+		qualifiers = append(qualifiers, ref.alias)
+	}
+
+	out := make([]SetClause, 0, len(set))
+
+	for _, clause := range set {
+		value := clause.value
+		rendered, args := renderDetached(ctx, value)
+		excluded := false
+
+		for i, inserted := range insertArm.values {
+			insertedSQL, insertedArgs := renderDetached(ctx, inserted)
+			if insertedSQL == rendered && reflect.DeepEqual(insertedArgs, args) {
+				value = Excluded(insertArm.columns[i])
+				excluded = true
+
+				break
+			}
+		}
+
+		if !excluded && referencesQualifier(rendered, qualifiers) {
+			detail := fmt.Sprintf("the upsert fallback cannot reference the source or target alias in SET %s", clause.column)
+			ctx.addError(newBuildError("MERGE", ErrUnsupportedByDialect, detail))
+		}
+
+		out = append(out, SetClause{column: clause.column, value: value})
+	}
+
+	return out
+}
+
+// referencesQualifier reports whether rendered SQL qualifies a column with any of the given names, quoted or not.
+func referencesQualifier(sql string, qualifiers []string) bool {
+	for _, qualifier := range qualifiers {
+		if qualifier == "" {
+			continue
+		}
+
+		pattern := `(^|[^\w$])["\x60\[]?` + regexp.QuoteMeta(qualifier) + `["\x60\]]?\.`
+		if regexp.MustCompile(pattern).MatchString(sql) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// renderDetached renders an expression on a scratch context so it can be compared without consuming placeholders.
+func renderDetached(ctx *buildContext, expr Expression) (string, []any) {
+	scratch := newBuildContext(ctx.dialect, ctx.mysqlReturning)
+	scratch.features = ctx.features
+	scratch.quoting = ctx.quoting
+	scratch.strict = ctx.strict
+	scratch.strictJoins = ctx.strictJoins
+
+	return expr.build(scratch), scratch.args
+}
@@ -0,0 +1,140 @@
+package chizuql
+
+import "testing"
+
+func mergeInventory(d Dialect) *Query {
+	return New().
+		WithDialect(d).
+		Merge(TableAlias("inventory", "t")).
+		Using(TableAlias("incoming", "s"), Col("t.sku").Eq(Col("s.sku"))).
+		WhenMatched(Col("s.qty").Eq(0)).Delete().
+		WhenMatched().Update(Set("qty", Col("s.qty"))).
+		WhenNotMatched(Col("s.qty").Gt(0)).Insert([]string{"sku", "qty"}, Col("s.sku"), Col("s.qty"))
+}
+
+func TestNativeMerge(t *testing.T) {
+	assertBuild(t, mergeInventory(DialectPostgres),
+		"MERGE INTO inventory AS t USING incoming AS s ON (t.sku = s.sku) "+
+			"WHEN MATCHED AND (s.qty = $1) THEN DELETE "+
+			"WHEN MATCHED THEN UPDATE SET qty = s.qty "+
+			"WHEN NOT MATCHED AND (s.qty > $2) THEN INSERT (sku, qty) VALUES (s.sku, s.qty)",
+		[]any{0, 0},
+	)
+
+	assertBuild(t, mergeInventory(DialectSQLServer).WhenNotMatched().DoNothing(),
+		"MERGE INTO inventory AS t USING incoming AS s ON (t.sku = s.sku) "+
+			"WHEN MATCHED AND (s.qty = @p1) THEN DELETE "+
+			"WHEN MATCHED THEN UPDATE SET qty = s.qty "+
+			"WHEN NOT MATCHED AND (s.qty > @p2) THEN INSERT (sku, qty) VALUES (s.sku, s.qty);",
+		[]any{0, 0},
+	)
+
+	oracle := New().
+		WithDialect(DialectOracle).
+		Merge(TableAlias("inventory", "t")).
+		Using(FromSubquery(New().Select("sku", "qty").From("incoming"), "s"), Col("t.sku").Eq(Col("s.sku"))).
+		WhenMatched(Col("t.qty").Ne(Col("s.qty"))).Update(Set("qty", Col("s.qty"))).
+		WhenNotMatched().Insert([]string{"sku", "qty"}, Col("s.sku"), Col("s.qty"))
+
+	assertBuild(t, oracle,
+		"MERGE INTO inventory t USING (SELECT sku, qty FROM incoming) s ON (t.sku = s.sku) "+
+			"WHEN MATCHED THEN UPDATE SET qty = s.qty WHERE (t.qty <> s.qty) "+
+			"WHEN NOT MATCHED THEN INSERT (sku, qty) VALUES (s.sku, s.qty)",
+		nil,
+	)
+
+	assertBuildError(t, mergeInventory(DialectOracle), ErrUnsupportedByDialect)
+}
+
+func TestMergeUpsertFallback(t *testing.T) {
+	upsert := func(d Dialect) *Query {
+		return New().
+			WithDialect(d).
+			Merge("inventory").
+			Using(TableAlias("incoming", "s"), Col("inventory.sku").Eq(Col("s.sku"))).
+			WhenMatched().Update(Set("qty", Col("s.qty")), Set("updated_at", Raw("CURRENT_TIMESTAMP"))).
+			WhenNotMatched().Insert([]string{"sku", "qty"}, Col("s.sku"), Col("s.qty"))
+	}
+
+	assertBuild(t, upsert(DialectMySQL),
+		"INSERT INTO inventory (sku, qty) SELECT s.sku, s.qty FROM incoming AS s "+
+			"ON DUPLICATE KEY UPDATE qty = VALUES(qty), updated_at = CURRENT_TIMESTAMP",
+		nil,
+	)
+
+	assertBuild(t, upsert(DialectSQLite),
+		"INSERT INTO inventory (sku, qty) SELECT s.sku, s.qty FROM incoming AS s WHERE true "+
+			"ON CONFLICT DO UPDATE SET qty = EXCLUDED.qty, updated_at = CURRENT_TIMESTAMP",
+		nil,
+	)
+
+	insertOnly := func(d Dialect) *Query {
+		return New().
+			WithDialect(d).
+			Merge(TableAlias("inventory", "t")).
+			Using("incoming", Col("t.sku").Eq(Col("incoming.sku"))).
+			WhenNotMatched().Insert([]string{"sku"}, Col("incoming.sku"))
+	}
+
+	assertBuild(t, insertOnly(DialectMySQL),
+		"INSERT INTO inventory (sku) SELECT incoming.sku FROM incoming ON DUPLICATE KEY UPDATE sku = sku",
+		nil,
+	)
+
+	assertBuild(t, insertOnly(DialectSQLite),
+		"INSERT INTO inventory (sku) SELECT incoming.sku FROM incoming WHERE true ON CONFLICT DO NOTHING",
+		nil,
+	)
+}
+
+func TestMergeErrors(t *testing.T) {
+	assertBuildError(t, mergeInventory(DialectMySQL), ErrUnsupportedByDialect)
+	assertBuildError(t, mergeInventory(DialectClickHouse), ErrUnsupportedByDialect)
+	assertBuildError(t,
+		New().WithDialect(DialectSQLite).Merge("inventory").Using("incoming", Raw("true")).WhenMatched().Update(Set("qty", 1)),
+		ErrUnsupportedByDialect,
+	)
+	assertBuildError(t, New().WithDialect(DialectPostgres).Merge("inventory").WhenMatched().Delete(), ErrInvalidClause)
+	assertBuildError(t, New().WithDialect(DialectPostgres).Merge("inventory").Using("incoming", Raw("true")), ErrInvalidClause)
+	assertBuildError(t,
+		New().WithDialect(DialectPostgres).Merge("inventory").Using("incoming", Raw("true")).
+			WhenNotMatched().Insert([]string{"sku", "qty"}, Col("incoming.sku")),
+		ErrMismatchedColumns,
+	)
+}
+
+func TestMergeUpsertFallbackRejectsAliasReferences(t *testing.T) {
+	accumulate := func(d Dialect) *Query {
+		return New().
+			WithDialect(d).
+			Merge(TableAlias("inventory", "t")).
+			Using(TableAlias("incoming", "s"), Col("t.sku").Eq(Col("s.sku"))).
+			WhenMatched().Update(Set("qty", Raw("t.qty + s.qty"))).
+			WhenNotMatched().Insert([]string{"sku", "qty"}, Col("s.sku"), Col("s.qty"))
+	}
+
+	assertBuildError(t, accumulate(DialectMySQL), ErrUnsupportedByDialect)
+	assertBuildError(t, accumulate(DialectSQLite), ErrUnsupportedByDialect)
+	assertBuildError(t,
+		New().
+			WithDialect(DialectSQLite).
+			WithIdentifierQuoting(IdentifierQuotingAll).
+			Merge("inventory").
+			Using(TableAlias("incoming", "s"), Col("inventory.sku").Eq(Col("s.sku"))).
+			WhenMatched().Update(Set("qty", Col("s.restock"))).
+			WhenNotMatched().Insert([]string{"sku", "qty"}, Col("s.sku"), Col("s.qty")),
+		ErrUnsupportedByDialect,
+	)
+
+	assertBuild(t,
+		New().
+			WithDialect(DialectMySQL).
+			Merge("inventory").
+			Using(TableAlias("incoming", "s"), Col("inventory.sku").Eq(Col("s.sku"))).
+			WhenMatched().Update(Set("qty", Raw("inventory.qty + 1"))).
+			WhenNotMatched().Insert([]string{"sku", "qty"}, Col("s.sku"), Col("s.qty")),
+		"INSERT INTO inventory (sku, qty) SELECT s.sku, s.qty FROM incoming AS s "+
+			"ON DUPLICATE KEY UPDATE qty = inventory.qty + 1",
+		nil,
+	)
+}
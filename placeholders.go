@@ -0,0 +1,214 @@
+package chizuql
+
+import (
+	"database/sql"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// rewritePlaceholders renders the ? markers of a raw fragment with the dialect placeholders, numbering them in
+// sequence with the rest of the query and binding args in order. Markers inside string literals, quoted identifiers
+// and comments are kept as-is, and ?? renders a literal ? (ex: the PostgreSQL jsonb ? operator).
+func rewritePlaceholders(ctx *buildContext, clause, sql string, args []any) string {
+	sb := strings.Builder{}
+	used := 0
+
+	for i := 0; i < len(sql); {
+		if end := skipSQLLiteral(sql, i, ctx.features.BackslashEscapes); end > i {
+			sb.WriteString(sql[i:end])
+			i = end
+
+			continue
+		}
+
+		if sql[i] != '?' {
+			sb.WriteByte(sql[i])
+			i++
+
+			continue
+		}
+
+		if i+1 < len(sql) && sql[i+1] == '?' {
+			sb.WriteByte('?')
+			i += 2
+
+			continue
+		}
+
+		if used < len(args) {
+			sb.WriteString(ctx.nextPlaceholder(args[used]))
+		}
+
+		used++
+		i++
+	}
+
+	if used != len(args) {
+		ctx.addError(newBuildError(clause, ErrPlaceholderMismatch,
+			fmt.Sprintf("%d placeholders, %d arguments", used, len(args))))
+	}
+
+	return sb.String()
+}
+
+// skipSQLLiteral returns the offset right after the string literal, quoted identifier or comment starting at i, or i
+// when none starts there. With backslashEscapes, a backslash inside a string literal escapes the next character.
+func skipSQLLiteral(sql string, i int, backslashEscapes bool) int {
+	switch {
+	case sql[i] == '\'' || sql[i] == '"' || sql[i] == '`':
+		quote := sql[i]
+
+		for j := i + 1; j < len(sql); j++ {
+			if backslashEscapes && quote != '`' && sql[j] == '\\' {
+				j++
+
+				continue
+			}
+
+			if sql[j] != quote {
+				continue
+			}
+
+			if j+1 < len(sql) && sql[j+1] == quote {
+				j++
+
+				continue
+			}
+
+			return j + 1
+		}
+
+		return len(sql)
+	case strings.HasPrefix(sql[i:], "--"):
+		if end := strings.IndexByte(sql[i:], '\n'); end >= 0 {
+			return i + end + 1
+		}
+
+		return len(sql)
+	case strings.HasPrefix(sql[i:], "/*"):
+		if end := strings.Index(sql[i+2:], "*/"); end >= 0 {
+			return i + 2 + end + 2
+		}
+
+		return len(sql)
+	default:
+		return i
+	}
+}
+
+// rewriteNamedPlaceholders renders :name and @name markers of a raw fragment with the dialect placeholders. Numbered
+// dialects reuse the same placeholder for repeated names; positional dialects bind the value once per occurrence.
+// :: casts, @@ variables and markers inside literals, quoted identifiers and comments are kept as-is.
+func rewriteNamedPlaceholders(ctx *buildContext, clause, sql string, args map[string]any) string {
+	sb := strings.Builder{}
+	bound := make(map[string]string, len(args))
+	missing := make([]string, 0)
+
+	for i := 0; i < len(sql); {
+		if end := skipSQLLiteral(sql, i, ctx.features.BackslashEscapes); end > i {
+			sb.WriteString(sql[i:end])
+			i = end
+
+			continue
+		}
+
+		marker := sql[i]
+		if marker != ':' && marker != '@' {
+			sb.WriteByte(marker)
+			i++
+
+			continue
+		}
+
+		if i+1 < len(sql) && sql[i+1] == marker {
+			sb.WriteString(sql[i : i+2])
+			i += 2
+
+			continue
+		}
+
+		end := i + 1
+		for end < len(sql) && isIdentifierByte(sql[end]) {
+			end++
+		}
+
+		name := sql[i+1 : end]
+		if !isPlainIdentifier(name) {
+			sb.WriteString(sql[i:end])
+			i = end
+
+			continue
+		}
+
+		value, ok := args[name]
+		if !ok {
+			missing = append(missing, string(marker)+name)
+			sb.WriteString(sql[i:end])
+			i = end
+
+			continue
+		}
+
+		placeholder, seen := bound[name]
+		if !seen || ctx.features.Placeholders != PlaceholderNumbered {
+			placeholder = ctx.nextPlaceholder(value)
+			bound[name] = placeholder
+		}
+
+		sb.WriteString(placeholder)
+		i = end
+	}
+
+	if len(missing) > 0 {
+		ctx.addError(newBuildError(clause, ErrNamedArgMismatch, "missing values for "+strings.Join(missing, ", ")))
+	}
+
+	unused := make([]string, 0)
+
+	for name := range args {
+		if _, ok := bound[name]; !ok {
+			unused = append(unused, name)
+		}
+	}
+
+	if len(unused) > 0 {
+		sort.Strings(unused)
+		ctx.addError(newBuildError(clause, ErrNamedArgMismatch, "unused arguments "+strings.Join(unused, ", ")))
+	}
+
+	return sb.String()
+}
+
+func isIdentifierByte(c byte) bool {
+	return c == '_' || c == '$' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9'
+}
+
+// namedArgs converts sql.NamedArg arguments into a name-value map. It returns nil when no argument is named and
+// fails when named and positional arguments are mixed.
+func namedArgs(clause string, args []any) (map[string]any, *BuildError) {
+	var named map[string]any
+
+	positional := 0
+
+	for _, arg := range args {
+		namedArg, ok := arg.(sql.NamedArg)
+		if !ok {
+			positional++
+
+			continue
+		}
+
+		if named == nil {
+			named = make(map[string]any, len(args))
+		}
+
+		named[namedArg.Name] = namedArg.Value
+	}
+
+	if named != nil && positional > 0 {
+		return nil, newBuildError(clause, ErrNamedArgMismatch, "cannot mix sql.NamedArg with positional arguments")
+	}
+
+	return named, nil
+}
@@ -0,0 +1,160 @@
+package chizuql
+
+import (
+	"database/sql"
+	"testing"
+)
+
+func TestRawPlaceholdersAreRenumbered(t *testing.T) {
+	assertBuild(t,
+		New().WithDialect(DialectPostgres).
+			Select("id").
+			From("events").
+			Where(
+				Col("tenant_id").Eq(7),
+				Raw("created_at > ? AND created_at < ?", "2025-01-01", "2025-02-01"),
+				Col("kind").Eq("click"),
+			),
+		"SELECT id FROM events WHERE (tenant_id = $1 AND created_at > $2 AND created_at < $3 AND kind = $4)",
+		[]any{7, "2025-01-01", "2025-02-01", "click"},
+	)
+
+	assertBuild(t,
+		New().WithDialect(DialectSQLServer).Select("id").From("events").Where(Raw("kind = ?", "view")).Limit(1),
+		"SELECT TOP (1) id FROM events WHERE (kind = @p1)",
+		[]any{"view"},
+	)
+}
+
+func TestRawPlaceholdersSkipLiteralsAndEscapes(t *testing.T) {
+	assertBuild(t,
+		New().WithDialect(DialectPostgres).
+			Select("id").
+			From("docs").
+			Where(Raw(`payload ?? 'tag' AND note <> 'what?' AND "odd?col" = ? /* why? */ AND title = ? -- trailing?
+AND owner = ?`, 1, "go", "ana")),
+		`SELECT id FROM docs WHERE (payload ? 'tag' AND note <> 'what?' AND "odd?col" = $1 /* why? */ AND title = $2 -- trailing?
+AND owner = $3)`,
+		[]any{1, "go", "ana"},
+	)
+
+	assertBuild(t,
+		New().WithDialect(DialectPostgres).Select("id").From("docs").Where(Raw("payload ?? 'tag'")),
+		"SELECT id FROM docs WHERE (payload ? 'tag')",
+		nil,
+	)
+
+	assertBuild(t,
+		RawQuery("SELECT id FROM docs WHERE payload ?? 'tag'").WithDialect(DialectPostgres),
+		"SELECT id FROM docs WHERE payload ? 'tag'",
+		nil,
+	)
+
+	assertBuildError(t,
+		New().WithDialect(DialectPostgres).Select("id").From("docs").Where(Raw("payload ? 'tag'")),
+		ErrPlaceholderMismatch,
+	)
+}
+
+func TestRawPlaceholdersBackslashEscapes(t *testing.T) {
+	assertBuild(t,
+		New().WithDialect(DialectMySQL).Select("id").From("users").Where(Raw(`name = '\'?' AND id = ?`, 1)),
+		`SELECT id FROM users WHERE (name = '\'?' AND id = ?)`,
+		[]any{1},
+	)
+
+	assertBuild(t,
+		New().WithDialect(DialectMySQL).Select("id").From("users").Where(RawNamed(`note = 'c:\\' AND id = :id`, map[string]any{"id": 2})),
+		`SELECT id FROM users WHERE (note = 'c:\\' AND id = ?)`,
+		[]any{2},
+	)
+
+	assertBuild(t,
+		New().WithDialect(DialectPostgres).Select("id").From("users").Where(Raw(`path = 'c:\' AND id = ?`, 3)),
+		`SELECT id FROM users WHERE (path = 'c:\' AND id = $1)`,
+		[]any{3},
+	)
+}
+
+func TestRawQueryPlaceholders(t *testing.T) {
+	assertBuild(t,
+		RawQuery("SELECT * FROM users WHERE id = ? AND name <> 'it''s ?'", 1).WithDialect(DialectOracle),
+		"SELECT * FROM users WHERE id = :1 AND name <> 'it''s ?'",
+		[]any{1},
+	)
+
+	assertBuild(t,
+		New().WithDialect(DialectPostgres).
+			Select("id").
+			From("users").
+			Where(Col("active").Eq(true), Col("id").In(RawQuery("SELECT user_id FROM bans WHERE until > ?", "now"))),
+		"SELECT id FROM users WHERE (active = $1 AND id IN (SELECT user_id FROM bans WHERE until > $2))",
+		[]any{true, "now"},
+	)
+}
+
+func TestRawPlaceholderMismatch(t *testing.T) {
+	assertBuildError(t,
+		New().WithDialect(DialectPostgres).Select("id").From("users").Where(Raw("id = ? OR parent_id = ?", 1)),
+		ErrPlaceholderMismatch,
+	)
+
+	assertBuildError(t, RawQuery("SELECT 1", 1), ErrPlaceholderMismatch)
+}
+
+func TestRawNamedPlaceholders(t *testing.T) {
+	snippet := RawNamed("tenant_id = :tenant_id AND (owner_id = :user OR created_by = :user) AND data::text <> ''", map[string]any{
+		"tenant_id": 3,
+		"user":      9,
+	})
+
+	assertBuild(t,
+		New().WithDialect(DialectPostgres).Select("id").From("docs").Where(Col("kind").Eq("pdf"), snippet),
+		"SELECT id FROM docs WHERE (kind = $1 AND tenant_id = $2 AND (owner_id = $3 OR created_by = $3) AND data::text <> '')",
+		[]any{"pdf", 3, 9},
+	)
+
+	assertBuild(t,
+		New().WithDialect(DialectMySQL).Select("id").From("docs").Where(snippet),
+		"SELECT id FROM docs WHERE (tenant_id = ? AND (owner_id = ? OR created_by = ?) AND data::text <> '')",
+		[]any{3, 9, 9},
+	)
+
+	assertBuild(t,
+		New().WithDialect(DialectSQLServer).Select("id").From("docs").
+			Where(Raw("tenant_id = @tenant AND ':skip' <> '' AND @@ROWCOUNT > 0", sql.Named("tenant", 3))),
+		"SELECT id FROM docs WHERE (tenant_id = @p1 AND ':skip' <> '' AND @@ROWCOUNT > 0)",
+		[]any{3},
+	)
+}
+
+func TestRawQueryNamedPlaceholders(t *testing.T) {
+	assertBuild(t,
+		RawQueryNamed("SELECT * FROM users WHERE tenant_id = :tenant AND id = :id", map[string]any{"tenant": 1, "id": 2}).
+			WithDialect(DialectOracle),
+		"SELECT * FROM users WHERE tenant_id = :1 AND id = :2",
+		[]any{1, 2},
+	)
+
+	assertBuild(t,
+		RawQuery("DELETE FROM sessions WHERE user_id = @user", sql.Named("user", 5)).WithDialect(DialectPostgres),
+		"DELETE FROM sessions WHERE user_id = $1",
+		[]any{5},
+	)
+}
+
+func TestNamedPlaceholderMismatch(t *testing.T) {
+	invalid := []*Query{
+		New().Select("id").From("docs").Where(RawNamed("tenant_id = :tenant", map[string]any{})),
+		New().Select("id").From("docs").Where(RawNamed("tenant_id = :tenant", map[string]any{"tenant": 1, "extra": 2})),
+		New().Select("id").From("docs").Where(Raw("tenant_id = :tenant AND id = ?", sql.Named("tenant", 1), 2)),
+		RawQuery("SELECT 1 WHERE :a = ?", sql.Named("a", 1), 2),
+		RawQueryNamed("SELECT :missing", map[string]any{}),
+		New().Select(RawNamed("x + :id", nil)).From("t"),
+		RawQueryNamed("SELECT :missing", nil),
+	}
+
+	for _, q := range invalid {
+		assertBuildError(t, q, ErrNamedArgMismatch)
+	}
+}
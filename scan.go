@@ -0,0 +1,294 @@
+package chizuql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"iter"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+)
+
+// All runs q on db and scans every row into a T.
+//
+// Struct types are mapped column by column (see Iter); other types receive the single result column.
+func All[T any](ctx context.Context, db Executor, q *Query) ([]T, error) {
+	items := make([]T, 0)
+
+	for item, err := range Iter[T](ctx, db, q) {
+		if err != nil {
+			return nil, err
+		}
+
+		items = append(items, item)
+	}
+
+	return items, nil
+}
+
+// One runs q on db and scans the first row into a T, returning sql.ErrNoRows when the query matched no rows.
+func One[T any](ctx context.Context, db Executor, q *Query) (T, error) {
+	for item, err := range Iter[T](ctx, db, q) {
+		return item, err
+	}
+
+	var zero T
+
+	return zero, sql.ErrNoRows
+}
+
+// Iter runs q on db and yields one T per row, stopping after the first error. Rows are closed when the iteration
+// ends, including when the caller breaks out of the loop.
+//
+// Result columns are matched case-insensitively against struct fields: the db tag when present (db:"-" skips the
+// field), otherwise the field name in snake_case. Embedded structs are flattened, while nested struct fields match
+// columns prefixed with their name and a dot or underscore (ex: author.name or author_name for a field tagged
+// db:"author"). Pointer fields receive NULLs, and sql.Scanner and time.Time fields are scanned as single values.
+// Columns without a matching field fail with ErrUnmappedColumn.
+func Iter[T any](ctx context.Context, db Executor, q *Query) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		var zero T
+
+		rows, err := q.QueryContext(ctx, db)
+		if err != nil {
+			yield(zero, err)
+
+			return
+		}
+
+		defer func() { _ = rows.Close() }()
+
+		scanner, err := newRowScanner(reflect.TypeFor[T](), rows)
+		if err != nil {
+			yield(zero, err)
+
+			return
+		}
+
+		for rows.Next() {
+			var item T
+
+			err := scanner.scan(rows, reflect.ValueOf(&item).Elem())
+			if !yield(item, err) || err != nil {
+				return
+			}
+		}
+
+		if err := rows.Err(); err != nil {
+			yield(zero, err)
+		}
+	}
+}
+
+var (
+	scannerType = reflect.TypeFor[sql.Scanner]()
+	timeType    = reflect.TypeFor[time.Time]()
+	fieldCache  sync.Map // map[reflect.Type]map[string][]int
+)
+
+// rowScanner holds the field index path for each result column.
+type rowScanner struct {
+	leaf  bool
+	paths [][]int
+}
+
+func newRowScanner(t reflect.Type, rows *sql.Rows) (*rowScanner, error) {
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+
+	if isLeafType(t) {
+		if len(columns) != 1 {
+			return nil, fmt.Errorf("%w: %d columns returned for %s", ErrUnmappedColumn, len(columns), t)
+		}
+
+		return &rowScanner{leaf: true}, nil
+	}
+
+	fields := structFields(indirectType(t))
+	paths := make([][]int, 0, len(columns))
+
+	for _, column := range columns {
+		path, ok := fields[strings.ToLower(column)]
+		if !ok {
+			return nil, fmt.Errorf("%w: %q has no matching field in %s", ErrUnmappedColumn, column, t)
+		}
+
+		paths = append(paths, path)
+	}
+
+	return &rowScanner{paths: paths}, nil
+}
+
+func (s *rowScanner) scan(rows *sql.Rows, item reflect.Value) error {
+	if s.leaf {
+		return rows.Scan(item.Addr().Interface())
+	}
+
+	if item.Kind() == reflect.Pointer {
+		item.Set(reflect.New(item.Type().Elem()))
+		item = item.Elem()
+	}
+
+	dests := make([]any, 0, len(s.paths))
+	for _, path := range s.paths {
+		dests = append(dests, fieldByIndexAlloc(item, path).Addr().Interface())
+	}
+
+	return rows.Scan(dests...)
+}
+
+// structFields maps lower-cased column names to field index paths for a struct type.
+func structFields(t reflect.Type) map[string][]int {
+	if cached, ok := fieldCache.Load(t); ok {
+		return cached.(map[string][]int)
+	}
+
+	fields := make(map[string][]int)
+	collectFields(t, []string{""}, nil, fields)
+	fieldCache.Store(t, fields)
+
+	return fields
+}
+
+// collectFields registers the fields of t under each prefix. Fields declared directly in t take precedence over
+// fields promoted from embedded or nested structs.
+func collectFields(t reflect.Type, prefixes []string, index []int, out map[string][]int) {
+	type nested struct {
+		field reflect.StructField
+		name  string
+	}
+
+	deferred := make([]nested, 0)
+
+	for i := range t.NumField() {
+		field := t.Field(i)
+		name := fieldTagName(field)
+
+		if name == "-" || (!field.IsExported() && !field.Anonymous) {
+			continue
+		}
+
+		// A nil pointer to an unexported embedded struct cannot be allocated through reflection, so its fields are
+		// skipped like encoding/json does.
+		if !field.IsExported() && field.Type.Kind() == reflect.Pointer {
+			continue
+		}
+
+		if !isLeafType(field.Type) && indirectType(field.Type).Kind() == reflect.Struct {
+			deferred = append(deferred, nested{field: field, name: name})
+
+			continue
+		}
+
+		if !field.IsExported() {
+			continue
+		}
+
+		if name == "" {
+			name = snakeCase(field.Name)
+		}
+
+		for _, prefix := range prefixes {
+			key := strings.ToLower(prefix + name)
+			if _, exists := out[key]; !exists {
+				out[key] = appendIndex(index, i)
+			}
+		}
+	}
+
+	for _, n := range deferred {
+		childPrefixes := prefixes
+
+		if !n.field.Anonymous || n.name != "" {
+			name := n.name
+			if name == "" {
+				name = snakeCase(n.field.Name)
+			}
+
+			childPrefixes = make([]string, 0, len(prefixes)*2)
+			for _, prefix := range prefixes {
+				childPrefixes = append(childPrefixes, prefix+name+".", prefix+name+"_")
+			}
+		}
+
+		collectFields(indirectType(n.field.Type), childPrefixes, appendIndex(index, n.field.Index[0]), out)
+	}
+}
+
+// fieldTagName returns the column name from the db tag, ignoring options.
+func fieldTagName(field reflect.StructField) string {
+	name, _, _ := strings.Cut(field.Tag.Get("db"), ",")
+
+	return name
+}
+
+func appendIndex(index []int, i int) []int {
+	path := make([]int, len(index), len(index)+1)
+	copy(path, index)
+
+	return append(path, i)
+}
+
+// fieldByIndexAlloc walks an index path, allocating nil pointers to nested structs on the way.
+func fieldByIndexAlloc(v reflect.Value, path []int) reflect.Value {
+	for _, i := range path {
+		if v.Kind() == reflect.Pointer {
+			if v.IsNil() {
+				v.Set(reflect.New(v.Type().Elem()))
+			}
+
+			v = v.Elem()
+		}
+
+		v = v.Field(i)
+	}
+
+	return v
+}
+
+// isLeafType reports whether values of t are scanned as a single column.
+func isLeafType(t reflect.Type) bool {
+	if t.Implements(scannerType) || reflect.PointerTo(t).Implements(scannerType) {
+		return true
+	}
+
+	base := indirectType(t)
+
+	return base == timeType || base.Kind() != reflect.Struct || reflect.PointerTo(base).Implements(scannerType)
+}
+
+func indirectType(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	return t
+}
+
+// snakeCase converts a Go field name into snake_case, keeping acronyms together (UserID becomes user_id).
+func snakeCase(name string) string {
+	runes := []rune(name)
+	sb := strings.Builder{}
+
+	for i, r := range runes {
+		if unicode.IsUpper(r) {
+			prevLower := i > 0 && (unicode.IsLower(runes[i-1]) || unicode.IsDigit(runes[i-1]))
+			nextLower := i > 0 && i+1 < len(runes) && unicode.IsLower(runes[i+1])
+
+			if prevLower || nextLower {
+				sb.WriteByte('_')
+			}
+
+			r = unicode.ToLower(r)
+		}
+
+		sb.WriteRune(r)
+	}
+
+	return sb.String()
+}
@@ -0,0 +1,169 @@
+package chizuql
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type auditFields struct {
+	CreatedAt time.Time  `db:"created_at"`
+	DeletedAt *time.Time `db:"deleted_at"`
+}
+
+type scannedAuthor struct {
+	ID   int64
+	Name string
+}
+
+type scannedPost struct {
+	auditFields
+
+	ID        int64          `db:"id"`
+	Title     string         `db:"title"`
+	Subtitle  *string        `db:"subtitle"`
+	Slug      sql.NullString `db:"slug"`
+	ViewCount int64
+	Author    scannedAuthor `db:"author"`
+	Ignored   string        `db:"-"`
+}
+
+func TestAllScansStructs(t *testing.T) {
+	created := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	fake := &fakeDB{
+		columns: []string{"id", "title", "subtitle", "slug", "view_count", "created_at", "deleted_at", "author.id", "author_name"},
+		rows: [][]driver.Value{
+			{int64(1), "Hello", "intro", "hello", int64(10), created, nil, int64(7), "Ana"},
+			{int64(2), "Bye", nil, nil, int64(0), created, created, int64(8), "Bia"},
+		},
+	}
+	db := fake.open(t)
+
+	q := New().WithDialect(DialectPostgres).
+		Select("p.id", "p.title", "p.subtitle", "p.slug", "p.view_count", "p.created_at", "p.deleted_at",
+			ColAlias("a.id", `"author.id"`), ColAlias("a.name", "author_name")).
+		From(TableAlias("posts", "p")).
+		Join(TableAlias("authors", "a"), Raw("a.id = p.author_id"))
+
+	posts, err := All[scannedPost](context.Background(), db, q)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(posts) != 2 {
+		t.Fatalf("expected 2 posts, got %d", len(posts))
+	}
+
+	first, second := posts[0], posts[1]
+
+	if first.ID != 1 || first.Title != "Hello" || first.Subtitle == nil || *first.Subtitle != "intro" ||
+		!first.Slug.Valid || first.ViewCount != 10 || !first.CreatedAt.Equal(created) || first.DeletedAt != nil {
+		t.Fatalf("unexpected first post: %+v", first)
+	}
+
+	if first.Author != (scannedAuthor{ID: 7, Name: "Ana"}) {
+		t.Fatalf("unexpected author: %+v", first.Author)
+	}
+
+	if second.Subtitle != nil || second.Slug.Valid || second.DeletedAt == nil || second.Author.Name != "Bia" {
+		t.Fatalf("unexpected second post: %+v", second)
+	}
+}
+
+func TestOneAndScalarScanning(t *testing.T) {
+	fake := &fakeDB{columns: []string{"ID", "NAME"}, rows: [][]driver.Value{{int64(3), "Caio"}}}
+	db := fake.open(t)
+	ctx := context.Background()
+
+	author, err := One[*scannedAuthor](ctx, db, New().Select("id", "name").From("authors").Limit(1))
+	if err != nil || author == nil || *author != (scannedAuthor{ID: 3, Name: "Caio"}) {
+		t.Fatalf("unexpected author: %+v, %v", author, err)
+	}
+
+	fake.columns = []string{"id"}
+	fake.rows = [][]driver.Value{{int64(1)}, {int64(2)}, {int64(3)}}
+
+	ids, err := All[int64](ctx, db, New().Select("id").From("authors"))
+	if err != nil || !reflect.DeepEqual(ids, []int64{1, 2, 3}) {
+		t.Fatalf("unexpected ids: %v, %v", ids, err)
+	}
+
+	fake.rows = nil
+
+	if _, err := One[int64](ctx, db, New().Select("id").From("authors")); !errors.Is(err, sql.ErrNoRows) {
+		t.Fatalf("expected sql.ErrNoRows, got %v", err)
+	}
+}
+
+func TestScanSkipsUnexportedEmbeddedPointers(t *testing.T) {
+	type row struct {
+		*auditFields
+
+		ID   int64
+		Name string
+	}
+
+	fake := &fakeDB{columns: []string{"id", "name"}, rows: [][]driver.Value{{int64(1), "Ana"}}}
+	db := fake.open(t)
+	ctx := context.Background()
+
+	got, err := One[row](ctx, db, New().Select("id", "name").From("authors"))
+	if err != nil || got.ID != 1 || got.Name != "Ana" || got.auditFields != nil {
+		t.Fatalf("unexpected row: %+v, %v", got, err)
+	}
+
+	fake.columns = []string{"id", "created_at"}
+	fake.rows = [][]driver.Value{{int64(1), time.Now()}}
+
+	if _, err := All[row](ctx, db, New().Select("id", "created_at").From("authors")); !errors.Is(err, ErrUnmappedColumn) {
+		t.Fatalf("expected ErrUnmappedColumn, got %v", err)
+	}
+}
+
+func TestIterStopsEarlyAndReportsErrors(t *testing.T) {
+	fake := &fakeDB{columns: []string{"id", "name"}, rows: [][]driver.Value{{int64(1), "a"}, {int64(2), "b"}, {int64(3), "c"}}}
+	db := fake.open(t)
+	ctx := context.Background()
+
+	seen := 0
+
+	for author, err := range Iter[scannedAuthor](ctx, db, New().Select("id", "name").From("authors")) {
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		seen++
+
+		if author.ID == 2 {
+			break
+		}
+	}
+
+	if seen != 2 {
+		t.Fatalf("expected iteration to stop after 2 rows, got %d", seen)
+	}
+
+	fake.columns = []string{"id", "unknown"}
+
+	if _, err := All[scannedAuthor](ctx, db, New().Select("id", "unknown").From("authors")); !errors.Is(err, ErrUnmappedColumn) {
+		t.Fatalf("expected ErrUnmappedColumn, got %v", err)
+	}
+
+	if _, err := All[scannedAuthor](ctx, db, New().Update("authors")); !errors.Is(err, ErrMissingSetClause) {
+		t.Fatalf("expected build error, got %v", err)
+	}
+}
+
+func TestSnakeCase(t *testing.T) {
+	cases := map[string]string{"ID": "id", "UserID": "user_id", "HTTPServer": "http_server", "ViewCount": "view_count", "Line2": "line2"}
+
+	for in, want := range cases {
+		if got := snakeCase(in); got != want {
+			t.Fatalf("snakeCase(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
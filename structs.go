@@ -0,0 +1,316 @@
+package chizuql
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// InsertStruct starts an INSERT query whose columns and values row are derived from the fields of v, a struct or
+// struct pointer.
+//
+// Columns follow the same naming rules used when scanning (db tag, otherwise the field name in snake_case, with
+// embedded structs flattened). Fields tagged db:"-", nested structs and unexported fields are ignored. The tag options
+// control which fields are written:
+//
+//   - omitempty skips the field when it holds its zero value;
+//   - readonly never writes the field (ex: columns filled by database defaults or triggers);
+//   - autoincrement never writes the field, leaving the key to the database (ex: db:"id,autoincrement").
+func (q *Query) InsertStruct(table any, v any) *Query {
+	q.InsertInto(table)
+
+	row, err := structValue(v)
+	if err != nil {
+		q.addError(newBuildError("INSERT INTO", ErrInvalidStruct, err.Error()))
+
+		return q
+	}
+
+	return q.insertStructRows([]reflect.Value{row})
+}
+
+// InsertStructs starts a multi-row INSERT query from rows, a slice of structs or struct pointers of the same type.
+//
+// Columns are derived as in InsertStruct. A field tagged omitempty is only skipped when it is zero in every row, so all
+// rows share the same column list.
+func (q *Query) InsertStructs(table any, rows any) *Query {
+	q.InsertInto(table)
+
+	list := reflect.ValueOf(rows)
+	if list.Kind() != reflect.Slice && list.Kind() != reflect.Array {
+		q.addError(newBuildError("INSERT INTO", ErrInvalidStruct, fmt.Sprintf("expected a slice of structs, got %T", rows)))
+
+		return q
+	}
+
+	if list.Len() == 0 {
+		q.addError(newBuildError("INSERT INTO", ErrInvalidStruct, "no rows to insert"))
+
+		return q
+	}
+
+	values := make([]reflect.Value, 0, list.Len())
+
+	for i := range list.Len() {
+		row, err := structValue(list.Index(i).Interface())
+		if err != nil {
+			q.addError(newBuildError("INSERT INTO", ErrInvalidStruct, fmt.Sprintf("row %d: %v", i, err)))
+
+			return q
+		}
+
+		if len(values) > 0 && row.Type() != values[0].Type() {
+			q.addError(newBuildError("INSERT INTO", ErrInvalidStruct,
+				fmt.Sprintf("row %d: %s does not match %s", i, row.Type(), values[0].Type())))
+
+			return q
+		}
+
+		values = append(values, row)
+	}
+
+	return q.insertStructRows(values)
+}
+
+func (q *Query) insertStructRows(rows []reflect.Value) *Query {
+	fields := make([]writeField, 0)
+
+	for _, field := range writeFields(rows[0].Type()) {
+		if field.readOnly || field.autoIncrement {
+			continue
+		}
+
+		if field.omitEmpty && !slices.ContainsFunc(rows, func(row reflect.Value) bool { return !field.value(row).IsZero() }) {
+			continue
+		}
+
+		fields = append(fields, field)
+	}
+
+	for _, field := range fields {
+		q.insertCols = append(q.insertCols, field.column)
+	}
+
+	for _, row := range rows {
+		values := make([]Expression, 0, len(fields))
+		for _, field := range fields {
+			values = append(values, Value(field.value(row).Interface()))
+		}
+
+		q.insertValues = append(q.insertValues, values)
+	}
+
+	return q
+}
+
+// UpdateStruct starts an UPDATE query that sets the non-zero fields of v, as in SetStruct.
+func (q *Query) UpdateStruct(table any, v any) *Query {
+	return q.Update(table).SetStruct(v)
+}
+
+// SetStruct adds SET clauses for the fields of v, a struct or struct pointer, that hold non-zero values. Columns are
+// derived as in InsertStruct, and readonly and autoincrement fields are never set.
+//
+// Use SetChanged to also write fields reset to their zero value.
+func (q *Query) SetStruct(v any) *Query {
+	row, err := structValue(v)
+	if err != nil {
+		q.addError(newBuildError("SET", ErrInvalidStruct, err.Error()))
+
+		return q
+	}
+
+	for _, field := range writeFields(row.Type()) {
+		value := field.value(row)
+		if field.readOnly || field.autoIncrement || value.IsZero() {
+			continue
+		}
+
+		q.setClauses = append(q.setClauses, Set(field.column, Value(value.Interface())))
+	}
+
+	return q
+}
+
+// SetChanged adds SET clauses for the fields whose values differ between before and after, two values of the same
+// struct type, writing the values from after. Readonly and autoincrement fields are never set.
+func (q *Query) SetChanged(before, after any) *Query {
+	old, current, err := structPair(before, after)
+	if err != nil {
+		q.addError(newBuildError("SET", ErrInvalidStruct, err.Error()))
+
+		return q
+	}
+
+	q.setChangedFields(old, current)
+
+	return q
+}
+
+func (q *Query) setChangedFields(before, after reflect.Value) {
+	for _, field := range writeFields(after.Type()) {
+		if field.readOnly || field.autoIncrement {
+			continue
+		}
+
+		value := field.value(after)
+		if reflect.DeepEqual(field.value(before).Interface(), value.Interface()) {
+			continue
+		}
+
+		q.setClauses = append(q.setClauses, Set(field.column, Value(value.Interface())))
+	}
+}
+
+var (
+	valuerType      = reflect.TypeFor[driver.Valuer]()
+	writeFieldCache sync.Map // map[reflect.Type][]writeField
+)
+
+// writeField describes a struct field written by the struct-driven INSERT and UPDATE helpers.
+type writeField struct {
+	column        string
+	typ           reflect.Type
+	index         []int
+	depth         int
+	omitEmpty     bool
+	readOnly      bool
+	autoIncrement bool
+}
+
+// value returns the field value in row, or its zero value when an embedded struct pointer on the way is nil.
+func (f writeField) value(row reflect.Value) reflect.Value {
+	v := row
+
+	for _, i := range f.index {
+		if v.Kind() == reflect.Pointer {
+			if v.IsNil() {
+				return reflect.Zero(f.typ)
+			}
+
+			v = v.Elem()
+		}
+
+		v = v.Field(i)
+	}
+
+	return v
+}
+
+// structValue dereferences v and reports an error unless it holds a struct.
+func structValue(v any) (reflect.Value, error) {
+	value := reflect.ValueOf(v)
+
+	for value.Kind() == reflect.Pointer {
+		if value.IsNil() {
+			return reflect.Value{}, fmt.Errorf("nil %T", v)
+		}
+
+		value = value.Elem()
+	}
+
+	if value.Kind() != reflect.Struct {
+		return reflect.Value{}, fmt.Errorf("expected a struct or struct pointer, got %T", v)
+	}
+
+	return value, nil
+}
+
+func structPair(before, after any) (reflect.Value, reflect.Value, error) {
+	old, err := structValue(before)
+	if err != nil {
+		return reflect.Value{}, reflect.Value{}, err
+	}
+
+	current, err := structValue(after)
+	if err != nil {
+		return reflect.Value{}, reflect.Value{}, err
+	}
+
+	if old.Type() != current.Type() {
+		return reflect.Value{}, reflect.Value{}, fmt.Errorf("%s does not match %s", current.Type(), old.Type())
+	}
+
+	return old, current, nil
+}
+
+// writeFields lists the column fields of a struct type in declaration order. When names collide, the shallowest
+// field wins, as with Go field promotion.
+func writeFields(t reflect.Type) []writeField {
+	if cached, ok := writeFieldCache.Load(t); ok {
+		return cached.([]writeField)
+	}
+
+	all := make([]writeField, 0, t.NumField())
+	collectWriteFields(t, nil, &all)
+
+	shallowest := make(map[string]int, len(all))
+	for _, field := range all {
+		if depth, ok := shallowest[field.column]; !ok || field.depth < depth {
+			shallowest[field.column] = field.depth
+		}
+	}
+
+	fields := make([]writeField, 0, len(all))
+	seen := make(map[string]bool, len(all))
+
+	for _, field := range all {
+		if field.depth != shallowest[field.column] || seen[field.column] {
+			continue
+		}
+
+		seen[field.column] = true
+		fields = append(fields, field)
+	}
+
+	writeFieldCache.Store(t, fields)
+
+	return fields
+}
+
+func collectWriteFields(t reflect.Type, index []int, out *[]writeField) {
+	for i := range t.NumField() {
+		field := t.Field(i)
+		tag, options, _ := strings.Cut(field.Tag.Get("db"), ",")
+
+		if tag == "-" {
+			continue
+		}
+
+		composite := !isWriteLeafType(field.Type) && indirectType(field.Type).Kind() == reflect.Struct
+		if composite {
+			if field.Anonymous && tag == "" {
+				collectWriteFields(indirectType(field.Type), appendIndex(index, i), out)
+			}
+
+			continue
+		}
+
+		if !field.IsExported() {
+			continue
+		}
+
+		if tag == "" {
+			tag = snakeCase(field.Name)
+		}
+
+		opts := strings.Split(options, ",")
+		*out = append(*out, writeField{
+			column:        tag,
+			typ:           field.Type,
+			index:         appendIndex(index, i),
+			depth:         len(index),
+			omitEmpty:     slices.Contains(opts, "omitempty"),
+			readOnly:      slices.Contains(opts, "readonly"),
+			autoIncrement: slices.Contains(opts, "autoincrement"),
+		})
+	}
+}
+
+// isWriteLeafType reports whether values of t are bound as a single argument.
+func isWriteLeafType(t reflect.Type) bool {
+	return t.Implements(valuerType) || reflect.PointerTo(t).Implements(valuerType) || isLeafType(t)
+}
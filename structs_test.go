@@ -0,0 +1,104 @@
+package chizuql
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+)
+
+type timestamps struct {
+	CreatedAt time.Time `db:"created_at,readonly"`
+	UpdatedAt *time.Time
+}
+
+type account struct {
+	*timestamps
+
+	ID       int64          `db:"id,autoincrement"`
+	Email    string         `db:"email"`
+	Nickname string         `db:"nickname,omitempty"`
+	Bio      sql.NullString `db:"bio"`
+	Owner    scannedAuthor
+	Internal string `db:"-"`
+	secret   string
+}
+
+func TestInsertStruct(t *testing.T) {
+	updated := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	acc := account{
+		timestamps: &timestamps{UpdatedAt: &updated},
+		ID:         10,
+		Email:      "ana@example.com",
+		secret:     "ignored",
+	}
+
+	q := New().WithDialect(DialectPostgres).InsertStruct("accounts", &acc).Returning("id")
+
+	assertBuild(t, q,
+		"INSERT INTO accounts (updated_at, email, bio) VALUES ($1, $2, $3) RETURNING id",
+		[]any{&updated, "ana@example.com", sql.NullString{}},
+	)
+
+	acc.timestamps = nil
+	acc.Nickname = "ana"
+
+	assertBuild(t, New().InsertStruct("accounts", acc),
+		"INSERT INTO accounts (updated_at, email, nickname, bio) VALUES (?, ?, ?, ?)",
+		[]any{(*time.Time)(nil), "ana@example.com", "ana", sql.NullString{}},
+	)
+}
+
+func TestInsertStructs(t *testing.T) {
+	rows := []*account{
+		{Email: "a@example.com"},
+		{Email: "b@example.com", Nickname: "bia"},
+	}
+
+	q := New().WithDialect(DialectPostgres).InsertStructs("accounts", rows)
+
+	assertBuild(t, q,
+		"INSERT INTO accounts (updated_at, email, nickname, bio) VALUES ($1, $2, $3, $4), ($5, $6, $7, $8)",
+		[]any{
+			(*time.Time)(nil), "a@example.com", "", sql.NullString{},
+			(*time.Time)(nil), "b@example.com", "bia", sql.NullString{},
+		},
+	)
+
+	assertBuild(t, New().InsertStructs("accounts", []account{{Email: "a@example.com"}}),
+		"INSERT INTO accounts (updated_at, email, bio) VALUES (?, ?, ?)",
+		[]any{(*time.Time)(nil), "a@example.com", sql.NullString{}},
+	)
+
+	assertBuildError(t, New().InsertStructs("accounts", []account{}), ErrInvalidStruct)
+	assertBuildError(t, New().InsertStructs("accounts", []any{account{}, scannedAuthor{}}), ErrInvalidStruct)
+	assertBuildError(t, New().InsertStructs("accounts", account{}), ErrInvalidStruct)
+	assertBuildError(t, New().InsertStruct("accounts", 42), ErrInvalidStruct)
+}
+
+func TestUpdateStructAndSetChanged(t *testing.T) {
+	acc := account{ID: 1, Email: "new@example.com", Bio: sql.NullString{String: "hi", Valid: true}}
+
+	q := New().WithDialect(DialectPostgres).UpdateStruct("accounts", acc).Where(Col("id").Eq(acc.ID))
+
+	assertBuild(t, q,
+		"UPDATE accounts SET email = $1, bio = $2 WHERE (id = $3)",
+		[]any{"new@example.com", sql.NullString{String: "hi", Valid: true}, int64(1)},
+	)
+
+	before := account{ID: 1, Email: "old@example.com", Nickname: "old"}
+	after := before
+	after.Nickname = ""
+	after.Email = "new@example.com"
+	after.ID = 2
+
+	q = New().WithDialect(DialectPostgres).Update("accounts").SetChanged(before, &after).Where(Col("id").Eq(before.ID))
+
+	assertBuild(t, q,
+		"UPDATE accounts SET email = $1, nickname = $2 WHERE (id = $3)",
+		[]any{"new@example.com", "", int64(1)},
+	)
+
+	assertBuildError(t, New().Update("accounts").SetChanged(before, scannedAuthor{}), ErrInvalidStruct)
+	assertBuildError(t, New().UpdateStruct("accounts", (*account)(nil)), ErrInvalidStruct)
+	assertBuildError(t, New().UpdateStruct("accounts", account{ID: 1}), ErrMissingSetClause)
+}